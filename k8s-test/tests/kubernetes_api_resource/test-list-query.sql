@@ -0,0 +1,15 @@
+select
+  name,
+  "group",
+  version,
+  api_version,
+  kind,
+  namespaced,
+  short_names
+from
+  kubernetes.kubernetes_api_resource
+where
+  api_version = 'v1'
+  and name in ('configmaps', 'pods')
+order by
+  name;
@@ -0,0 +1,7 @@
+select
+  name,
+  api_version
+from
+  kubernetes.kubernetes_api_resource
+where
+  name = 'steampipe-test-not-found';
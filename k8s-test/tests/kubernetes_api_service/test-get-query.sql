@@ -0,0 +1,12 @@
+select
+  name,
+  "group",
+  version,
+  local,
+  service_name,
+  available_status,
+  available_reason
+from
+  kubernetes.kubernetes_api_service
+where
+  name = 'v1.apps';
@@ -0,0 +1,13 @@
+select
+  name,
+  "group",
+  version,
+  local,
+  service_name,
+  available_status,
+  available_reason
+from
+  kubernetes.kubernetes_api_service
+where
+  "group" = 'apps'
+  and version = 'v1';
@@ -0,0 +1,5 @@
+resource "null_resource" "delete_certificate_signing_request" {
+  provisioner "local-exec" {
+    command = "kubectl delete -f ${path.cwd}/certificate_signing_request.yaml"
+  }
+}
@@ -0,0 +1,15 @@
+select
+  name,
+  signer_name,
+  usages,
+  request_common_name,
+  request_organizations,
+  request_dns_names,
+  request_ip_addresses,
+  request_key_algorithm,
+  approved,
+  certificate_issued
+from
+  kubernetes.kubernetes_certificate_signing_request
+where
+  signer_name = 'example.com/steampipe-test';
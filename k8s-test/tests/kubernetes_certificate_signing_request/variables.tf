@@ -0,0 +1,22 @@
+resource "null_resource" "create_certificate_signing_request" {
+  provisioner "local-exec" {
+    command = "kubectl apply -f ${path.cwd}/certificate_signing_request.yaml"
+  }
+}
+
+resource "null_resource" "delay" {
+  provisioner "local-exec" {
+    command = "sleep 45"
+  }
+}
+
+
+# Delay in order to get the resource creation complete
+resource "null_resource" "get_certificate_signing_request" {
+  depends_on = [
+    null_resource.delay
+  ]
+  provisioner "local-exec" {
+    command = "kubectl get csr steampipe-test"
+  }
+}
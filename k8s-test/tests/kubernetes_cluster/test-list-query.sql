@@ -0,0 +1,7 @@
+select
+  major,
+  node_count > 0 as has_nodes,
+  server is not null as has_server,
+  git_version like 'v1.%' as has_git_version
+from
+  kubernetes.kubernetes_cluster;
@@ -0,0 +1,15 @@
+select
+  pod_name,
+  namespace,
+  name,
+  container_type,
+  image,
+  cpu_request,
+  memory_request
+from
+  kubernetes.kubernetes_container
+where
+  pod_name = 'container-demo'
+  and namespace = 'default'
+order by
+  name;
@@ -0,0 +1,13 @@
+select
+  pod_name,
+  namespace,
+  name,
+  container_type,
+  image,
+  cpu_request,
+  memory_request
+from
+  kubernetes.kubernetes_container
+where
+  pod_name = ''
+  and namespace = '';
@@ -0,0 +1,22 @@
+resource "null_resource" "create_pod" {
+  provisioner "local-exec" {
+    command = "kubectl apply -f ${path.cwd}/pod.yaml"
+  }
+}
+
+resource "null_resource" "delay" {
+  provisioner "local-exec" {
+    command = "sleep 45"
+  }
+}
+
+
+# Delay in order to get the resource creation complete
+resource "null_resource" "get_pod" {
+  depends_on = [
+    null_resource.delay
+  ]
+  provisioner "local-exec" {
+    command = "kubectl get pod container-demo"
+  }
+}
@@ -0,0 +1,5 @@
+resource "null_resource" "delete_pod" {
+  provisioner "local-exec" {
+    command = "kubectl delete -f ${path.cwd}/pod.yaml"
+  }
+}
@@ -0,0 +1,15 @@
+select
+  pod_name,
+  namespace,
+  name,
+  container_type,
+  state,
+  state_reason,
+  restart_count
+from
+  kubernetes.kubernetes_container_status
+where
+  pod_name = 'container-status-demo'
+  and namespace = 'default'
+order by
+  name;
@@ -0,0 +1,13 @@
+select
+  pod_name,
+  namespace,
+  name,
+  container_type,
+  state,
+  state_reason,
+  restart_count
+from
+  kubernetes.kubernetes_container_status
+where
+  pod_name = ''
+  and namespace = '';
@@ -0,0 +1,10 @@
+select
+  name,
+  ready,
+  started
+from
+  kubernetes.kubernetes_container_status
+where
+  pod_name = 'container-status-demo'
+  and namespace = 'default'
+  and container_type = 'regular';
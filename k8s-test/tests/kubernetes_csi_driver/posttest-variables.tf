@@ -0,0 +1,5 @@
+resource "null_resource" "delete_csi_driver" {
+  provisioner "local-exec" {
+    command = "kubectl delete -f ${path.cwd}/csi_driver.yaml"
+  }
+}
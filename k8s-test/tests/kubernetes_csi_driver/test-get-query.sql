@@ -0,0 +1,9 @@
+select
+  name,
+  attach_required,
+  pod_info_on_mount,
+  volume_lifecycle_modes
+from
+  kubernetes.kubernetes_csi_driver
+where
+  name = 'steampipe-test.csi.example.com';
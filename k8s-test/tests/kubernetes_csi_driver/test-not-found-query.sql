@@ -0,0 +1,7 @@
+select
+  name,
+  attach_required
+from
+  kubernetes.kubernetes_csi_driver
+where
+  name = '';
@@ -0,0 +1,22 @@
+resource "null_resource" "create_csi_driver" {
+  provisioner "local-exec" {
+    command = "kubectl apply -f ${path.cwd}/csi_driver.yaml"
+  }
+}
+
+resource "null_resource" "delay" {
+  provisioner "local-exec" {
+    command = "sleep 45"
+  }
+}
+
+
+# Delay in order to get the resource creation complete
+resource "null_resource" "get_csi_driver" {
+  depends_on = [
+    null_resource.delay
+  ]
+  provisioner "local-exec" {
+    command = "kubectl get csidriver steampipe-test.csi.example.com"
+  }
+}
@@ -0,0 +1,7 @@
+select
+  count(*) as orphan_csi_nodes
+from
+  kubernetes.kubernetes_csi_node as c
+  left join kubernetes.kubernetes_node as n on n.name = c.name
+where
+  n.name is null;
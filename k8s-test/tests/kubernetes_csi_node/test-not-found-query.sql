@@ -0,0 +1,7 @@
+select
+  name,
+  driver_names
+from
+  kubernetes.kubernetes_csi_node
+where
+  name = '';
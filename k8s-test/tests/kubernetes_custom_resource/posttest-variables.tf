@@ -0,0 +1,14 @@
+resource "null_resource" "delete_widgets" {
+  provisioner "local-exec" {
+    command = "kubectl delete -f ${path.cwd}/widgets.yaml"
+  }
+}
+
+resource "null_resource" "delete_widget_crd" {
+  depends_on = [
+    null_resource.delete_widgets
+  ]
+  provisioner "local-exec" {
+    command = "kubectl delete -f ${path.cwd}/widget_crd.yaml"
+  }
+}
@@ -0,0 +1,11 @@
+select
+  name,
+  namespace,
+  color,
+  size,
+  spec
+from
+  kubernetes.kubernetes_widget
+where
+  name = 'gadget'
+  and namespace = 'default';
@@ -0,0 +1,11 @@
+select
+  name,
+  namespace,
+  color,
+  size
+from
+  kubernetes.kubernetes_widget
+where
+  namespace = 'default'
+order by
+  name;
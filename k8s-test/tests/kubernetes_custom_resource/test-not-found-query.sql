@@ -0,0 +1,10 @@
+select
+  name,
+  namespace,
+  color,
+  size
+from
+  kubernetes.kubernetes_widget
+where
+  name = ''
+  and namespace = '';
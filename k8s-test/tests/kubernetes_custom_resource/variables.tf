@@ -0,0 +1,54 @@
+resource "null_resource" "create_widget_crd" {
+  provisioner "local-exec" {
+    command = "kubectl apply -f ${path.cwd}/widget_crd.yaml"
+  }
+}
+
+resource "null_resource" "wait_widget_crd" {
+  depends_on = [
+    null_resource.create_widget_crd
+  ]
+  provisioner "local-exec" {
+    command = "kubectl wait --for condition=established --timeout=60s crd/widgets.steampipe.io"
+  }
+}
+
+resource "null_resource" "create_widgets" {
+  depends_on = [
+    null_resource.wait_widget_crd
+  ]
+  provisioner "local-exec" {
+    command = "kubectl apply -f ${path.cwd}/widgets.yaml"
+  }
+}
+
+# Custom resource tables are created when the plugin loads, so stop any running
+# service for the next query to load the plugin with the kubernetes_widget table
+resource "null_resource" "reload_plugin" {
+  depends_on = [
+    null_resource.create_widgets
+  ]
+  provisioner "local-exec" {
+    command = "steampipe service stop --force || true"
+  }
+}
+
+resource "null_resource" "delay" {
+  depends_on = [
+    null_resource.reload_plugin
+  ]
+  provisioner "local-exec" {
+    command = "sleep 45"
+  }
+}
+
+
+# Delay in order to get the resource creation complete
+resource "null_resource" "get_widgets" {
+  depends_on = [
+    null_resource.delay
+  ]
+  provisioner "local-exec" {
+    command = "kubectl get widgets"
+  }
+}
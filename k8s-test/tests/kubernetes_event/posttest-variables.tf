@@ -0,0 +1,5 @@
+resource "null_resource" "delete_event" {
+  provisioner "local-exec" {
+    command = "kubectl delete -f ${path.cwd}/event.yaml"
+  }
+}
@@ -0,0 +1,14 @@
+select
+  name,
+  namespace,
+  involved_object_kind,
+  involved_object_name,
+  reason,
+  type,
+  message,
+  count
+from
+  kubernetes.kubernetes_event
+where
+  reason = 'Testing'
+  and involved_object_name = 'event-demo';
@@ -0,0 +1,22 @@
+resource "null_resource" "create_event" {
+  provisioner "local-exec" {
+    command = "kubectl apply -f ${path.cwd}/event.yaml"
+  }
+}
+
+resource "null_resource" "delay" {
+  provisioner "local-exec" {
+    command = "sleep 45"
+  }
+}
+
+
+# Delay in order to get the resource creation complete
+resource "null_resource" "get_event" {
+  depends_on = [
+    null_resource.delay
+  ]
+  provisioner "local-exec" {
+    command = "kubectl get events --field-selector reason=Testing"
+  }
+}
@@ -0,0 +1,5 @@
+resource "null_resource" "delete_ingress_class" {
+  provisioner "local-exec" {
+    command = "kubectl delete -f ${path.cwd}/ingress_class.yaml"
+  }
+}
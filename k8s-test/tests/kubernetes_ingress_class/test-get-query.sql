@@ -0,0 +1,8 @@
+select
+  name,
+  controller,
+  is_default
+from
+  kubernetes.kubernetes_ingress_class
+where
+  name = 'steampipe-test';
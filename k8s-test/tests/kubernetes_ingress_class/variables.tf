@@ -0,0 +1,22 @@
+resource "null_resource" "create_ingress_class" {
+  provisioner "local-exec" {
+    command = "kubectl apply -f ${path.cwd}/ingress_class.yaml"
+  }
+}
+
+resource "null_resource" "delay" {
+  provisioner "local-exec" {
+    command = "sleep 45"
+  }
+}
+
+
+# Delay in order to get the resource creation complete
+resource "null_resource" "get_ingress_class" {
+  depends_on = [
+    null_resource.delay
+  ]
+  provisioner "local-exec" {
+    command = "kubectl get ingressclass steampipe-test"
+  }
+}
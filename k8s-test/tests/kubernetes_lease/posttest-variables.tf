@@ -0,0 +1,5 @@
+resource "null_resource" "delete_lease" {
+  provisioner "local-exec" {
+    command = "kubectl delete -f ${path.cwd}/lease.yaml"
+  }
+}
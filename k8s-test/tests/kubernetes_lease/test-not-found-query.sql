@@ -0,0 +1,13 @@
+select
+  name,
+  namespace,
+  holder_identity,
+  lease_duration_seconds,
+  lease_transitions,
+  seconds_since_renew > 15 as renewed_before_duration,
+  expired
+from
+  kubernetes.kubernetes_lease
+where
+  name = ''
+  and namespace = '';
@@ -0,0 +1,9 @@
+select
+  count(*) as invalid_metrics
+from
+  kubernetes.kubernetes_node_metric as m
+  left join kubernetes.kubernetes_node as n on n.name = m.name
+where
+  n.name is null
+  or m.cpu_usage_millicores < 0
+  or m.memory_usage_bytes < 0;
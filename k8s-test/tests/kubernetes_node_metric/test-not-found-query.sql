@@ -0,0 +1,8 @@
+select
+  name,
+  cpu_usage_millicores,
+  memory_usage_bytes
+from
+  kubernetes.kubernetes_node_metric
+where
+  name = '';
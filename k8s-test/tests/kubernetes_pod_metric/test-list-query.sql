@@ -0,0 +1,9 @@
+select
+  count(*) as invalid_metrics
+from
+  kubernetes.kubernetes_pod_metric
+where
+  pod_name is null
+  or container_name is null
+  or cpu_usage_millicores < 0
+  or memory_usage_bytes < 0;
@@ -0,0 +1,11 @@
+select
+  namespace,
+  pod_name,
+  container_name,
+  cpu_usage_millicores,
+  memory_usage_bytes
+from
+  kubernetes.kubernetes_pod_metric
+where
+  pod_name = ''
+  and namespace = '';
@@ -0,0 +1,5 @@
+resource "null_resource" "delete_priority_class" {
+  provisioner "local-exec" {
+    command = "kubectl delete -f ${path.cwd}/priority_class.yaml"
+  }
+}
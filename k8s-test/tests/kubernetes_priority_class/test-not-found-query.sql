@@ -0,0 +1,10 @@
+select
+  name,
+  value,
+  global_default,
+  description,
+  preemption_policy
+from
+  kubernetes.kubernetes_priority_class
+where
+  name = '';
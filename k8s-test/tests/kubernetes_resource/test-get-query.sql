@@ -0,0 +1,14 @@
+select
+  name,
+  namespace,
+  api_version,
+  kind,
+  resource,
+  object -> 'data' as data
+from
+  kubernetes.kubernetes_resource
+where
+  api_version = 'v1'
+  and kind = 'ConfigMap'
+  and name = 'resource-demo'
+  and namespace = 'default';
@@ -0,0 +1,12 @@
+select
+  name,
+  namespace,
+  api_version,
+  kind,
+  resource,
+  object -> 'data' as data
+from
+  kubernetes.kubernetes_resource
+where
+  resource = 'configmaps'
+  and name = 'resource-demo';
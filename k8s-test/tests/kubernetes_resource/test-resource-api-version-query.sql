@@ -0,0 +1,13 @@
+select
+  name,
+  namespace,
+  api_version,
+  kind,
+  resource,
+  object -> 'data' as data
+from
+  kubernetes.kubernetes_resource
+where
+  resource = 'configmaps'
+  and api_version = 'v1'
+  and name = 'resource-demo';
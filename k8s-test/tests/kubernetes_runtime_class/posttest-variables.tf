@@ -0,0 +1,5 @@
+resource "null_resource" "delete_runtime_class" {
+  provisioner "local-exec" {
+    command = "kubectl delete -f ${path.cwd}/runtime_class.yaml"
+  }
+}
@@ -0,0 +1,7 @@
+select
+  name,
+  handler
+from
+  kubernetes.kubernetes_runtime_class
+where
+  name = 'steampipe-test';
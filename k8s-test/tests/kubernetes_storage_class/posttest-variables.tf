@@ -0,0 +1,5 @@
+resource "null_resource" "delete_storage_class" {
+  provisioner "local-exec" {
+    command = "kubectl delete -f ${path.cwd}/storage_class.yaml"
+  }
+}
@@ -0,0 +1,12 @@
+select
+  name,
+  provisioner,
+  reclaim_policy,
+  volume_binding_mode,
+  allow_volume_expansion,
+  parameters,
+  is_default
+from
+  kubernetes.kubernetes_storage_class
+where
+  name = 'steampipe-test';
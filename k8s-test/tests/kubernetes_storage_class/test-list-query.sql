@@ -0,0 +1,7 @@
+select
+  name,
+  provisioner
+from
+  kubernetes.kubernetes_storage_class
+where
+  provisioner = 'example.com/steampipe-test';
@@ -0,0 +1,5 @@
+resource "null_resource" "delete_volume_attachment" {
+  provisioner "local-exec" {
+    command = "kubectl delete -f ${path.cwd}/volume_attachment.yaml"
+  }
+}
@@ -0,0 +1,10 @@
+select
+  name,
+  attacher,
+  node_name,
+  persistent_volume_name,
+  coalesce(attached, false) as attached
+from
+  kubernetes.kubernetes_volume_attachment
+where
+  name = 'steampipe-test';
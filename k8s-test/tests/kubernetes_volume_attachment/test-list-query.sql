@@ -0,0 +1,7 @@
+select
+  name,
+  attacher
+from
+  kubernetes.kubernetes_volume_attachment
+where
+  node_name = 'steampipe-test-node';
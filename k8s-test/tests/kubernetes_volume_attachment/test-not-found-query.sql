@@ -0,0 +1,7 @@
+select
+  name,
+  attacher
+from
+  kubernetes.kubernetes_volume_attachment
+where
+  name = '';
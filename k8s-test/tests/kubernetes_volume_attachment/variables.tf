@@ -0,0 +1,22 @@
+resource "null_resource" "create_volume_attachment" {
+  provisioner "local-exec" {
+    command = "kubectl apply -f ${path.cwd}/volume_attachment.yaml"
+  }
+}
+
+resource "null_resource" "delay" {
+  provisioner "local-exec" {
+    command = "sleep 45"
+  }
+}
+
+
+# Delay in order to get the resource creation complete
+resource "null_resource" "get_volume_attachment" {
+  depends_on = [
+    null_resource.delay
+  ]
+  provisioner "local-exec" {
+    command = "kubectl get volumeattachment steampipe-test"
+  }
+}
@@ -0,0 +1,272 @@
+package kubernetes
+
+import (
+	"context"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// admissionWebhookInfo :: the configuration a webhook belongs to, shared by the rows of the webhook tables
+type admissionWebhookInfo struct {
+	ConfigurationName string
+	ConfigurationUID  types.UID
+
+	// labels of the kube-system namespace, to evaluate namespace selectors against
+	kubeSystemLabels labels.Set
+}
+
+// admissionWebhook :: the fields the validating and mutating webhook rows have in common
+type admissionWebhook interface {
+	webhookClientConfig() admissionregistrationv1.WebhookClientConfig
+	webhookFailurePolicy() *admissionregistrationv1.FailurePolicyType
+	webhookNamespaceSelector() *metav1.LabelSelector
+	webhookInfo() admissionWebhookInfo
+}
+
+func newAdmissionWebhookInfo(configuration metav1.ObjectMeta, kubeSystemLabels labels.Set) admissionWebhookInfo {
+	return admissionWebhookInfo{
+		ConfigurationName: configuration.Name,
+		ConfigurationUID:  configuration.UID,
+		kubeSystemLabels:  kubeSystemLabels,
+	}
+}
+
+// admissionWebhookColumns :: columns of the validating and mutating webhook tables, with the
+// columns only one of them has inserted before the derived columns
+func admissionWebhookColumns(columns ...*plugin.Column) []*plugin.Column {
+	allColumns := []*plugin.Column{
+		{
+			Name:        "name",
+			Type:        proto.ColumnType_STRING,
+			Description: "Name of the webhook, unique within its configuration, e.g. validate.example.com.",
+		},
+		{
+			Name:        "configuration_name",
+			Type:        proto.ColumnType_STRING,
+			Description: "Name of the webhook configuration the webhook belongs to.",
+		},
+		{
+			Name:        "configuration_uid",
+			Type:        proto.ColumnType_STRING,
+			Description: "UID of the webhook configuration the webhook belongs to.",
+			Transform:   transform.FromField("ConfigurationUID").Transform(transform.NullIfZeroValue),
+		},
+		{
+			Name:        "url",
+			Type:        proto.ColumnType_STRING,
+			Description: "URL of the webhook, if it is not called through a service.",
+			Transform:   transform.FromField("ClientConfig.URL"),
+		},
+		{
+			Name:        "service_namespace",
+			Type:        proto.ColumnType_STRING,
+			Description: "Namespace of the service the webhook is called through.",
+			Transform:   transform.FromField("ClientConfig.Service.Namespace"),
+		},
+		{
+			Name:        "service_name",
+			Type:        proto.ColumnType_STRING,
+			Description: "Name of the service the webhook is called through.",
+			Transform:   transform.FromField("ClientConfig.Service.Name"),
+		},
+		{
+			Name:        "service_path",
+			Type:        proto.ColumnType_STRING,
+			Description: "URL path requests are sent to on the service.",
+			Transform:   transform.FromField("ClientConfig.Service.Path"),
+		},
+		{
+			Name:        "service_port",
+			Type:        proto.ColumnType_INT,
+			Description: "Port of the service the webhook is called on. Defaults to 443.",
+			Transform:   transform.FromField("ClientConfig.Service.Port"),
+		},
+		{
+			Name:        "has_ca_bundle",
+			Type:        proto.ColumnType_BOOL,
+			Description: "True if a CA bundle is set to validate the webhook's server certificate. If not, the API server's trust roots are used.",
+			Transform:   transform.From(transformAdmissionWebhookHasCABundle),
+		},
+		{
+			Name:        "failure_policy",
+			Type:        proto.ColumnType_STRING,
+			Description: "How errors calling the webhook are handled: Fail (the default) rejects the request, Ignore allows it.",
+			Transform:   transform.FromField("FailurePolicy"),
+		},
+		{
+			Name:        "match_policy",
+			Type:        proto.ColumnType_STRING,
+			Description: "How rules match requests for other versions of a resource: Equivalent (the default) or Exact.",
+			Transform:   transform.FromField("MatchPolicy"),
+		},
+		{
+			Name:        "side_effects",
+			Type:        proto.ColumnType_STRING,
+			Description: "Whether calling the webhook has side effects: None or NoneOnDryRun. Dry run requests are rejected for webhooks with side effects.",
+			Transform:   transform.FromField("SideEffects"),
+		},
+		{
+			Name:        "timeout_seconds",
+			Type:        proto.ColumnType_INT,
+			Description: "Number of seconds to wait for the webhook before applying the failure policy. Defaults to 10.",
+			Transform:   transform.FromField("TimeoutSeconds"),
+		},
+		{
+			Name:        "admission_review_versions",
+			Type:        proto.ColumnType_JSON,
+			Description: "Versions of AdmissionReview the webhook accepts, in order of preference.",
+			Transform:   transform.FromField("AdmissionReviewVersions"),
+		},
+		{
+			Name:        "rules",
+			Type:        proto.ColumnType_JSON,
+			Description: "Operations, API groups, versions, resources and scope the webhook is called for.",
+			Transform:   transform.FromField("Rules"),
+		},
+		{
+			Name:        "namespace_selector",
+			Type:        proto.ColumnType_JSON,
+			Description: "Label selector restricting the webhook to objects in matching namespaces. Empty matches every namespace.",
+			Transform:   transform.FromField("NamespaceSelector"),
+		},
+		{
+			Name:        "object_selector",
+			Type:        proto.ColumnType_JSON,
+			Description: "Label selector restricting the webhook to objects with matching labels. Empty matches every object.",
+			Transform:   transform.FromField("ObjectSelector"),
+		},
+	}
+
+	allColumns = append(allColumns, columns...)
+
+	allColumns = append(allColumns, []*plugin.Column{
+		{
+			Name:        "fails_closed_on_kube_system",
+			Type:        proto.ColumnType_BOOL,
+			Description: "True if the webhook fails closed and its namespace selector does not exclude kube-system, so an unavailable webhook can block changes to system components.",
+			Transform:   transform.From(transformAdmissionWebhookFailsClosedOnKubeSystem),
+		},
+		{
+			Name:        "service_has_no_ready_endpoints",
+			Type:        proto.ColumnType_BOOL,
+			Description: "True if the webhook is called through a service which has no ready endpoints, or does not exist. Null for webhooks called by URL.",
+			Hydrate:     getAdmissionWebhookServiceHasNoReadyEndpoints,
+			Transform:   transform.FromValue(),
+		},
+	}...)
+
+	allColumns = append(allColumns, kubectlConfigColumns()...)
+	allColumns = append(allColumns, sourceColumns()...)
+
+	return allColumns
+}
+
+//// HYDRATE FUNCTIONS
+
+// getKubeSystemNamespaceLabels :: labels of the kube-system namespace. If it cannot be read, the
+// label set on every namespace since Kubernetes 1.21 is assumed.
+func getKubeSystemNamespaceLabels(ctx context.Context, d *plugin.QueryData) (labels.Set, error) {
+	defaultLabels := labels.Set{"kubernetes.io/metadata.name": "kube-system"}
+
+	if isManifestSource(d) {
+		return defaultLabels, nil
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := clientset.CoreV1().Namespaces().Get(ctx, "kube-system", metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsForbidden(err) || isNotFoundError(err) {
+			plugin.Logger(ctx).Debug("getKubeSystemNamespaceLabels", "namespace_error", err)
+			return defaultLabels, nil
+		}
+		return nil, err
+	}
+
+	return labels.Merge(defaultLabels, namespace.Labels), nil
+}
+
+func getAdmissionWebhookServiceHasNoReadyEndpoints(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("getAdmissionWebhookServiceHasNoReadyEndpoints")
+
+	// endpoints are not known for manifest sources
+	if isManifestSource(d) {
+		return nil, nil
+	}
+
+	service := h.Item.(admissionWebhook).webhookClientConfig().Service
+	if service == nil {
+		return nil, nil
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return serviceHasNoReadyEndpoints(ctx, clientset, service)
+}
+
+// serviceHasNoReadyEndpoints :: true if the service has no endpoints or none of them are
+// ready, nil if the caller may not read its endpoints
+func serviceHasNoReadyEndpoints(ctx context.Context, clientset kubernetes.Interface, service *admissionregistrationv1.ServiceReference) (interface{}, error) {
+	endpoints, err := clientset.CoreV1().Endpoints(service.Namespace).Get(ctx, service.Name, metav1.GetOptions{})
+	if err != nil {
+		if isNotFoundError(err) {
+			return true, nil
+		}
+		if apierrors.IsForbidden(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func transformAdmissionWebhookFailsClosedOnKubeSystem(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	webhook := d.HydrateItem.(admissionWebhook)
+
+	// webhooks fail closed by default
+	failurePolicy := webhook.webhookFailurePolicy()
+	if failurePolicy != nil && *failurePolicy == admissionregistrationv1.Ignore {
+		return false, nil
+	}
+
+	// no selector matches every namespace
+	namespaceSelector := webhook.webhookNamespaceSelector()
+	if namespaceSelector == nil {
+		return true, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(namespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	return selector.Matches(webhook.webhookInfo().kubeSystemLabels), nil
+}
+
+func transformAdmissionWebhookHasCABundle(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	webhook := d.HydrateItem.(admissionWebhook)
+	return len(webhook.webhookClientConfig().CABundle) > 0, nil
+}
@@ -0,0 +1,245 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func TestTransformAdmissionWebhookFailsClosedOnKubeSystem(t *testing.T) {
+	fail := admissionregistrationv1.Fail
+	ignore := admissionregistrationv1.Ignore
+
+	kubeSystemLabels := labels.Set{
+		"kubernetes.io/metadata.name": "kube-system",
+		"control-plane":               "true",
+	}
+
+	tests := []struct {
+		name              string
+		failurePolicy     *admissionregistrationv1.FailurePolicyType
+		namespaceSelector *metav1.LabelSelector
+		want              interface{}
+		wantErr           bool
+	}{
+		{
+			name: "default failure policy without selector",
+			want: true,
+		},
+		{
+			name:          "fail without selector",
+			failurePolicy: &fail,
+			want:          true,
+		},
+		{
+			name:          "ignore without selector",
+			failurePolicy: &ignore,
+			want:          false,
+		},
+		{
+			name:          "ignore with a selector matching kube-system",
+			failurePolicy: &ignore,
+			namespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"kubernetes.io/metadata.name": "kube-system"},
+			},
+			want: false,
+		},
+		{
+			name:              "empty selector matches kube-system",
+			namespaceSelector: &metav1.LabelSelector{},
+			want:              true,
+		},
+		{
+			name: "selector excluding kube-system",
+			namespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "kubernetes.io/metadata.name", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"kube-system", "kube-public"}},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "selector excluding other namespaces",
+			namespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "kubernetes.io/metadata.name", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"cert-manager"}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "selector excluding namespaces with a label kube-system has",
+			namespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "control-plane", Operator: metav1.LabelSelectorOpDoesNotExist},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "selector requiring a label kube-system lacks",
+			namespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"webhooks.example.com/enabled": "true"},
+			},
+			want: false,
+		},
+		{
+			name: "invalid selector",
+			namespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "kubernetes.io/metadata.name", Operator: "Excludes", Values: []string{"kube-system"}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info := admissionWebhookInfo{ConfigurationName: "example", kubeSystemLabels: kubeSystemLabels}
+
+			webhooks := map[string]admissionWebhook{
+				"validating": ValidatingWebhook{
+					ValidatingWebhook: admissionregistrationv1.ValidatingWebhook{
+						Name:              "validate.example.com",
+						FailurePolicy:     test.failurePolicy,
+						NamespaceSelector: test.namespaceSelector,
+					},
+					admissionWebhookInfo: info,
+				},
+				"mutating": MutatingWebhook{
+					MutatingWebhook: admissionregistrationv1.MutatingWebhook{
+						Name:              "mutate.example.com",
+						FailurePolicy:     test.failurePolicy,
+						NamespaceSelector: test.namespaceSelector,
+					},
+					admissionWebhookInfo: info,
+				},
+			}
+
+			for kind, webhook := range webhooks {
+				got, err := transformAdmissionWebhookFailsClosedOnKubeSystem(context.Background(), &transform.TransformData{HydrateItem: webhook})
+				if test.wantErr {
+					if err == nil {
+						t.Errorf("%s: transformAdmissionWebhookFailsClosedOnKubeSystem() = %v, want an error", kind, got)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("%s: transformAdmissionWebhookFailsClosedOnKubeSystem() error = %v", kind, err)
+				}
+				if got != test.want {
+					t.Errorf("%s: transformAdmissionWebhookFailsClosedOnKubeSystem() = %v, want %v", kind, got, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestServiceHasNoReadyEndpoints(t *testing.T) {
+	service := &admissionregistrationv1.ServiceReference{Namespace: "webhooks", Name: "validator"}
+	endpointsMeta := metav1.ObjectMeta{Namespace: "webhooks", Name: "validator"}
+	endpointsResource := schema.GroupResource{Resource: "endpoints"}
+
+	readyAddress := v1.EndpointAddress{IP: "10.0.0.10"}
+	notReadyAddress := v1.EndpointAddress{IP: "10.0.0.11"}
+	port := v1.EndpointPort{Port: 8443}
+
+	tests := []struct {
+		name      string
+		endpoints *v1.Endpoints
+		getErr    error
+		want      interface{}
+		wantErr   bool
+	}{
+		{
+			name: "ready address",
+			endpoints: &v1.Endpoints{ObjectMeta: endpointsMeta, Subsets: []v1.EndpointSubset{
+				{Addresses: []v1.EndpointAddress{readyAddress}, Ports: []v1.EndpointPort{port}},
+			}},
+			want: false,
+		},
+		{
+			name: "ready and not ready addresses",
+			endpoints: &v1.Endpoints{ObjectMeta: endpointsMeta, Subsets: []v1.EndpointSubset{
+				{Addresses: []v1.EndpointAddress{readyAddress}, NotReadyAddresses: []v1.EndpointAddress{notReadyAddress}, Ports: []v1.EndpointPort{port}},
+			}},
+			want: false,
+		},
+		{
+			name: "ready address in a later subset",
+			endpoints: &v1.Endpoints{ObjectMeta: endpointsMeta, Subsets: []v1.EndpointSubset{
+				{NotReadyAddresses: []v1.EndpointAddress{notReadyAddress}, Ports: []v1.EndpointPort{port}},
+				{Addresses: []v1.EndpointAddress{readyAddress}, Ports: []v1.EndpointPort{{Port: 9443}}},
+			}},
+			want: false,
+		},
+		{
+			name: "only not ready addresses",
+			endpoints: &v1.Endpoints{ObjectMeta: endpointsMeta, Subsets: []v1.EndpointSubset{
+				{NotReadyAddresses: []v1.EndpointAddress{notReadyAddress}, Ports: []v1.EndpointPort{port}},
+			}},
+			want: true,
+		},
+		{
+			name:      "no subsets",
+			endpoints: &v1.Endpoints{ObjectMeta: endpointsMeta},
+			want:      true,
+		},
+		{
+			name: "missing endpoints",
+			want: true,
+		},
+		{
+			name:   "forbidden",
+			getErr: apierrors.NewForbidden(endpointsResource, "validator", errors.New("access denied")),
+			want:   nil,
+		},
+		{
+			name:    "other error",
+			getErr:  apierrors.NewServiceUnavailable("try again later"),
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			objects := []runtime.Object{}
+			if test.endpoints != nil {
+				objects = append(objects, test.endpoints)
+			}
+			clientset := fake.NewSimpleClientset(objects...)
+			if test.getErr != nil {
+				clientset.PrependReactor("get", "endpoints", func(k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, test.getErr
+				})
+			}
+
+			got, err := serviceHasNoReadyEndpoints(context.Background(), clientset, service)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("serviceHasNoReadyEndpoints() = %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("serviceHasNoReadyEndpoints() error = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("serviceHasNoReadyEndpoints() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
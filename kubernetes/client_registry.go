@@ -0,0 +1,86 @@
+package kubernetes
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+// clientKey identifies a client in the registry. Connections pointing at
+// different kubeconfig files or contexts never share an entry.
+type clientKey struct {
+	kind        string
+	connection  string
+	host        string
+	configPaths string
+	contextName string
+}
+
+// clientRegistry holds the clients and kubeconfigs built for each connection.
+// Unlike the connection cache, entries are never evicted; they are only
+// dropped when the connection config changes.
+type clientRegistry struct {
+	mu      sync.RWMutex
+	clients map[clientKey]interface{}
+}
+
+var clients = &clientRegistry{clients: map[clientKey]interface{}{}}
+
+func (r *clientRegistry) get(key clientKey) (interface{}, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	client, ok := r.clients[key]
+	return client, ok
+}
+
+func (r *clientRegistry) set(key clientKey, client interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.clients[key] = client
+}
+
+// clearConnection :: drop every client built for the given connection
+func (r *clientRegistry) clearConnection(connection string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key := range r.clients {
+		if key.connection == connection {
+			delete(r.clients, key)
+		}
+	}
+}
+
+// newClientKey :: registry key for a client of the given kind for the connection and context
+func newClientKey(connection *plugin.Connection, kind string, contextName string) clientKey {
+	kubernetesConfig := GetConfig(connection)
+
+	key := clientKey{
+		kind:        kind,
+		connection:  connection.Name,
+		configPaths: strings.Join(getKubeconfigPaths(kubernetesConfig), string(filepath.ListSeparator)),
+		contextName: contextName,
+	}
+	if kubernetesConfig.Host != nil {
+		key.host = *kubernetesConfig.Host
+	}
+
+	return key
+}
+
+// connectionConfigChanged :: rebuild clients for a connection whose config was edited, without restarting the plugin
+func connectionConfigChanged(ctx context.Context, p *plugin.Plugin, old, new *plugin.Connection) error {
+	plugin.Logger(ctx).Debug("connectionConfigChanged", "connection", new.Name)
+	clients.clearConnection(new.Name)
+
+	// clear the connection and query cache for this connection, which also drops the
+	// resolved contexts and decoded manifest objects
+	p.ClearConnectionCache(ctx, new.Name)
+	p.ClearQueryCache(ctx, new.Name)
+	return nil
+}
@@ -62,8 +62,36 @@ func kubectlConfigColumns() []*plugin.Column {
 			Name:        "context_name",
 			Type:        proto.ColumnType_STRING,
 			Description: "Kubectl config context name.",
-			Hydrate:     getKubectlContext,
-			Transform:   transform.FromValue(),
+			Transform:   transform.FromMatrixItem(matrixKeyContext).Transform(transform.NullIfZeroValue),
+		},
+	}
+}
+
+func sourceColumns() []*plugin.Column {
+	return []*plugin.Column{
+		{
+			Name:        "path",
+			Type:        proto.ColumnType_STRING,
+			Description: "Path to the manifest file, chart template or kustomize overlay the object was read from.",
+			Transform:   transform.FromField("Path").Transform(transform.NullIfZeroValue),
+		},
+		{
+			Name:        "source_type",
+			Type:        proto.ColumnType_STRING,
+			Description: "Where the object was read from: manifest, helm or kustomize. Null for objects read from the API server.",
+			Transform:   transform.FromField("SourceType").Transform(transform.NullIfZeroValue),
+		},
+		{
+			Name:        "chart_name",
+			Type:        proto.ColumnType_STRING,
+			Description: "Name of the Helm chart that rendered the object.",
+			Transform:   transform.FromField("ChartName").Transform(transform.NullIfZeroValue),
+		},
+		{
+			Name:        "chart_version",
+			Type:        proto.ColumnType_STRING,
+			Description: "Version of the Helm chart that rendered the object.",
+			Transform:   transform.FromField("ChartVersion").Transform(transform.NullIfZeroValue),
 		},
 	}
 }
@@ -76,6 +104,7 @@ func k8sCommonColumns(columns []*plugin.Column) []*plugin.Column {
 	//allColumns = append(allColumns, specStatusColumns...)
 	allColumns = append(allColumns, objectMetadataSecondaryColumns()...)
 	allColumns = append(allColumns, kubectlConfigColumns()...)
+	allColumns = append(allColumns, sourceColumns()...)
 
 	return allColumns
 }
@@ -88,6 +117,7 @@ func k8sCommonGlobalColumns(columns []*plugin.Column) []*plugin.Column {
 	//allColumns = append(allColumns, specStatusColumns...)
 	allColumns = append(allColumns, objectMetadataSecondaryColumns()...)
 	allColumns = append(allColumns, kubectlConfigColumns()...)
+	allColumns = append(allColumns, sourceColumns()...)
 
 	return allColumns
 }
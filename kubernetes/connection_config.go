@@ -6,9 +6,52 @@ import (
 )
 
 type kubernetesConfig struct {
-	ConfigPaths   []string `cty:"config_paths"`
-	ConfigPath    *string  `cty:"config_path"`
-	ConfigContext *string  `cty:"config_context"`
+	ConfigPaths    []string `cty:"config_paths"`
+	ConfigPath     *string  `cty:"config_path"`
+	ConfigContext  *string  `cty:"config_context"`
+	ConfigContexts []string `cty:"config_contexts"`
+
+	// Namespaces queried by namespaced tables
+	Namespaces        []string `cty:"namespaces"`
+	ExcludeNamespaces []string `cty:"exclude_namespaces"`
+
+	// Inline credentials, used instead of a kubeconfig when host is set
+	Host                 *string `cty:"host"`
+	Token                *string `cty:"token"`
+	TokenFile            *string `cty:"token_file"`
+	ClientCertificate    *string `cty:"client_certificate"`
+	ClientKey            *string `cty:"client_key"`
+	ClusterCACertificate *string `cty:"cluster_ca_certificate"`
+	Insecure             *bool   `cty:"insecure"`
+	TLSServerName        *string `cty:"tls_server_name"`
+
+	// Impersonation applied to every client of the connection
+	ImpersonateUser   *string  `cty:"impersonate_user"`
+	ImpersonateGroups []string `cty:"impersonate_groups"`
+	ImpersonateUID    *string  `cty:"impersonate_uid"`
+
+	// Exec credential plugin, used instead of the kubeconfig user entry
+	ExecCommand    *string  `cty:"exec_command"`
+	ExecArgs       []string `cty:"exec_args"`
+	ExecEnv        []string `cty:"exec_env"`
+	ExecAPIVersion *string  `cty:"exec_api_version"`
+
+	// Client-side rate limiting, timeouts and retries
+	QPS            *float64 `cty:"qps"`
+	Burst          *int     `cty:"burst"`
+	RequestTimeout *string  `cty:"request_timeout"`
+	MaxRetries     *int     `cty:"max_retries"`
+
+	// Manifest files, Helm charts and kustomizations served by the tables instead of a live cluster
+	ManifestFilePaths    []string `cty:"manifest_file_paths"`
+	HelmChartDirs        []string `cty:"helm_chart_dirs"`
+	HelmValuesFiles      []string `cty:"helm_values_files"`
+	HelmReleaseName      *string  `cty:"helm_release_name"`
+	HelmReleaseNamespace *string  `cty:"helm_release_namespace"`
+	KustomizePaths       []string `cty:"kustomize_paths"`
+
+	// Custom resource definitions which get a table, by name pattern
+	CustomResourceTables []string `cty:"custom_resource_tables"`
 }
 
 var ConfigSchema = map[string]*schema.Attribute{
@@ -22,6 +65,104 @@ var ConfigSchema = map[string]*schema.Attribute{
 	"config_context": {
 		Type: schema.TypeString,
 	},
+	"config_contexts": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"namespaces": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"exclude_namespaces": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"manifest_file_paths": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"helm_chart_dirs": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"helm_values_files": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"helm_release_name": {
+		Type: schema.TypeString,
+	},
+	"helm_release_namespace": {
+		Type: schema.TypeString,
+	},
+	"kustomize_paths": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"custom_resource_tables": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"host": {
+		Type: schema.TypeString,
+	},
+	"token": {
+		Type: schema.TypeString,
+	},
+	"token_file": {
+		Type: schema.TypeString,
+	},
+	"client_certificate": {
+		Type: schema.TypeString,
+	},
+	"client_key": {
+		Type: schema.TypeString,
+	},
+	"cluster_ca_certificate": {
+		Type: schema.TypeString,
+	},
+	"insecure": {
+		Type: schema.TypeBool,
+	},
+	"tls_server_name": {
+		Type: schema.TypeString,
+	},
+	"impersonate_user": {
+		Type: schema.TypeString,
+	},
+	"impersonate_groups": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"impersonate_uid": {
+		Type: schema.TypeString,
+	},
+	"exec_command": {
+		Type: schema.TypeString,
+	},
+	"exec_args": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"exec_env": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"exec_api_version": {
+		Type: schema.TypeString,
+	},
+	"qps": {
+		Type: schema.TypeFloat,
+	},
+	"burst": {
+		Type: schema.TypeInt,
+	},
+	"request_timeout": {
+		Type: schema.TypeString,
+	},
+	"max_retries": {
+		Type: schema.TypeInt,
+	},
 }
 
 func ConfigInstance() interface{} {
@@ -0,0 +1,239 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iancoleman/strcase"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+// customResourceDefinitionsTimeout bounds listing the definitions of all contexts. Tables are
+// defined when the connection is loaded, so an unresponsive cluster must not block Steampipe.
+const customResourceDefinitionsTimeout = 30 * time.Second
+
+// getCustomResourceTables :: one table per custom resource definition installed in the
+// contexts of the connection, or defined in its manifest sources. Tables are named
+// kubernetes_<singular name>, qualified with the API group when the name is taken.
+func getCustomResourceTables(ctx context.Context, connection *plugin.Connection, tables map[string]*plugin.Table) ([]customResourceTable, error) {
+	kubernetesConfig := GetConfig(connection)
+
+	definitions, err := getCustomResourceDefinitions(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	crdTables := []customResourceTable{}
+	for _, definition := range definitions {
+		if kubernetesConfig.CustomResourceTables != nil {
+			ok, err := matchCustomResourceTables(kubernetesConfig.CustomResourceTables, definition.Name)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		version, ok := getCustomResourceVersion(definition)
+		if !ok {
+			continue
+		}
+
+		crdTables = append(crdTables, customResourceTable{
+			Name:       "kubernetes_" + toColumnName(getCustomResourceSingularName(definition)),
+			Definition: definition,
+			Version:    version,
+		})
+	}
+
+	// names shared with a built-in table or another definition are qualified with the group
+	counts := map[string]int{}
+	for _, crdTable := range crdTables {
+		counts[crdTable.Name]++
+	}
+	for i, crdTable := range crdTables {
+		if _, ok := tables[crdTable.Name]; ok || counts[crdTable.Name] > 1 {
+			crdTables[i].Name = crdTable.Name + "_" + toColumnName(crdTable.Definition.Spec.Group)
+		}
+	}
+
+	return crdTables, nil
+}
+
+// getCustomResourceDefinitions :: definitions of every context queried by the connection,
+// sorted by name. A definition installed in several contexts is only returned once.
+func getCustomResourceDefinitions(ctx context.Context, connection *plugin.Connection) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	kubernetesConfig := GetConfig(connection)
+
+	definitions := map[string]apiextensionsv1.CustomResourceDefinition{}
+
+	if hasManifestSources(kubernetesConfig) {
+		manifests, err := getManifestObjects(ctx, connection)
+		if err != nil {
+			return nil, err
+		}
+
+		crdGroupKind := schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+		for _, manifest := range manifests {
+			gvk := schema.FromAPIVersionAndKind(stringValue(manifest.Object["apiVersion"]), stringValue(manifest.Object["kind"]))
+			if gvk.GroupKind() != crdGroupKind {
+				continue
+			}
+
+			var definition apiextensionsv1.CustomResourceDefinition
+			if err := manifest.decode(&definition); err != nil {
+				return nil, fmt.Errorf("%s: %v", manifest.Path, err)
+			}
+			if _, ok := definitions[definition.Name]; !ok {
+				definitions[definition.Name] = definition
+			}
+		}
+	} else {
+		contexts, err := getConfiguredContexts(ctx, connection)
+		if err != nil {
+			return nil, err
+		}
+
+		// contexts are listed at once, within a single deadline
+		ctx, cancel := context.WithTimeout(ctx, customResourceDefinitionsTimeout)
+		defer cancel()
+
+		contextDefinitions := make([][]apiextensionsv1.CustomResourceDefinition, len(contexts))
+		contextErrors := make([]error, len(contexts))
+
+		var wg sync.WaitGroup
+		for i, contextName := range contexts {
+			wg.Add(1)
+			go func(i int, contextName string) {
+				defer wg.Done()
+				contextDefinitions[i], contextErrors[i] = listCustomResourceDefinitions(ctx, connection, contextName)
+			}(i, contextName)
+		}
+		wg.Wait()
+
+		// merge in the order of the contexts, so the first context defining a name wins
+		for i, contextName := range contexts {
+			if err := contextErrors[i]; err != nil {
+				// as for queries, an unavailable context matched by a pattern is skipped
+				if isSkippableContextError(kubernetesConfig, contextName, err) {
+					plugin.Logger(ctx).Warn("skipping custom resource tables of unavailable context", "context", contextName, "error", err)
+					continue
+				}
+				return nil, fmt.Errorf("context %q: %v", contextName, err)
+			}
+
+			for _, definition := range contextDefinitions[i] {
+				if _, ok := definitions[definition.Name]; !ok {
+					definitions[definition.Name] = definition
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(definitions))
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sorted := make([]apiextensionsv1.CustomResourceDefinition, len(names))
+	for i, name := range names {
+		sorted[i] = definitions[name]
+	}
+
+	return sorted, nil
+}
+
+func listCustomResourceDefinitions(ctx context.Context, connection *plugin.Connection, contextName string) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	clientset, err := getNewClientCRDForContext(ctx, connection, contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	definitions := []apiextensionsv1.CustomResourceDefinition{}
+	pageLeft := true
+	for pageLeft {
+		response, err := clientset.ApiextensionsV1().CustomResourceDefinitions().List(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+		} else {
+			pageLeft = false
+		}
+
+		definitions = append(definitions, response.Items...)
+	}
+
+	return definitions, nil
+}
+
+// getCustomResourceVersion :: the version a table queries, i.e. the served storage
+// version, or the first served version if the storage version is not served
+func getCustomResourceVersion(definition apiextensionsv1.CustomResourceDefinition) (apiextensionsv1.CustomResourceDefinitionVersion, bool) {
+	var served []apiextensionsv1.CustomResourceDefinitionVersion
+	for _, version := range definition.Spec.Versions {
+		if !version.Served {
+			continue
+		}
+		if version.Storage {
+			return version, true
+		}
+		served = append(served, version)
+	}
+
+	if len(served) == 0 {
+		return apiextensionsv1.CustomResourceDefinitionVersion{}, false
+	}
+	return served[0], true
+}
+
+func getCustomResourceSingularName(definition apiextensionsv1.CustomResourceDefinition) string {
+	if definition.Spec.Names.Singular != "" {
+		return definition.Spec.Names.Singular
+	}
+	return strings.ToLower(definition.Spec.Names.Kind)
+}
+
+// matchCustomResourceTables :: whether a definition name matches one of the `custom_resource_tables` patterns
+func matchCustomResourceTables(patterns []string, name string) (bool, error) {
+	for _, pattern := range patterns {
+		ok, err := path.Match(pattern, name)
+		if err != nil {
+			return false, fmt.Errorf("invalid custom_resource_tables pattern %q: %v", pattern, err)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+var (
+	pluralAcronym          = regexp.MustCompile(`([A-Z]{2,})s([A-Z0-9]|$)`)
+	invalidColumnNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+)
+
+// toColumnName :: snake case name for a table or column, e.g. maxReplicas -> max_replicas,
+// podIPs -> pod_ips and cert-manager.io -> cert_manager_io
+func toColumnName(name string) string {
+	name = pluralAcronym.ReplaceAllString(name, "${1}S$2")
+	return strings.Trim(invalidColumnNameChars.ReplaceAllString(strcase.ToSnake(name), "_"), "_")
+}
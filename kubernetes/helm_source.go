@@ -0,0 +1,131 @@
+package kubernetes
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+const defaultHelmReleaseNamespace = "default"
+
+// renderHelmChart :: render a local chart directory with the Helm template engine, the
+// same way as `helm template`, but without hooks. The values files and release options of the
+// connection apply to every chart. No cluster is contacted, so lookup functions return
+// empty results and capabilities are Helm's defaults.
+func renderHelmChart(chartDir string, kubernetesConfig kubernetesConfig) ([]manifestObject, error) {
+	chartDir, err := homedir.Expand(chartDir)
+	if err != nil {
+		return nil, err
+	}
+
+	chart, err := loader.Load(chartDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart %s: %v", chartDir, err)
+	}
+
+	// values files are applied in order, so later files override earlier ones
+	values := map[string]interface{}{}
+	for _, valuesFile := range kubernetesConfig.HelmValuesFiles {
+		valuesFile, err := homedir.Expand(valuesFile)
+		if err != nil {
+			return nil, err
+		}
+
+		fileValues, err := chartutil.ReadValuesFile(valuesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values file %s: %v", valuesFile, err)
+		}
+		values = chartutil.CoalesceTables(fileValues, values)
+	}
+
+	if err := chartutil.ProcessDependencies(chart, values); err != nil {
+		return nil, fmt.Errorf("failed to process dependencies of chart %s: %v", chartDir, err)
+	}
+
+	releaseOptions := chartutil.ReleaseOptions{
+		Name:      chart.Name(),
+		Namespace: defaultHelmReleaseNamespace,
+		Revision:  1,
+		IsInstall: true,
+	}
+	if kubernetesConfig.HelmReleaseName != nil {
+		releaseOptions.Name = *kubernetesConfig.HelmReleaseName
+	}
+	if kubernetesConfig.HelmReleaseNamespace != nil {
+		releaseOptions.Namespace = *kubernetesConfig.HelmReleaseNamespace
+	}
+
+	renderValues, err := chartutil.ToRenderValues(chart, values, releaseOptions, chartutil.DefaultCapabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := engine.Render(chart, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render chart %s: %v", chartDir, err)
+	}
+
+	// render in a stable order, like helm does for its manifest output
+	templates := make([]string, 0, len(rendered))
+	for name := range rendered {
+		templates = append(templates, name)
+	}
+	sort.Strings(templates)
+
+	manifests := []manifestObject{}
+	for _, name := range templates {
+		// partials and notes are not manifests
+		if strings.HasPrefix(path.Base(name), "_") || strings.HasSuffix(name, "NOTES.txt") {
+			continue
+		}
+
+		objects, err := decodeManifests(strings.NewReader(rendered[name]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %v", name, err)
+		}
+
+		// template names are prefixed with the chart name rather than its directory
+		templatePath := filepath.Join(chartDir, filepath.FromSlash(strings.TrimPrefix(name, chart.Name()+"/")))
+
+		for _, object := range objects {
+			// hooks, including chart tests, are run by helm around an install rather than
+			// installed with the release
+			if isHelmHook(object) {
+				continue
+			}
+
+			// helm sets the namespace of objects at install time, not in the templates
+			if metadata, ok := object["metadata"].(map[string]interface{}); ok && stringValue(metadata["namespace"]) == "" {
+				metadata["namespace"] = releaseOptions.Namespace
+			}
+
+			manifests = append(manifests, manifestObject{
+				Object: object,
+				sourceInfo: sourceInfo{
+					Path:         templatePath,
+					SourceType:   sourceTypeHelm,
+					ChartName:    chart.Metadata.Name,
+					ChartVersion: chart.Metadata.Version,
+				},
+			})
+		}
+	}
+
+	return manifests, nil
+}
+
+// isHelmHook :: whether the object is a hook, i.e. it has the helm.sh/hook annotation
+func isHelmHook(object map[string]interface{}) bool {
+	metadata, _ := object["metadata"].(map[string]interface{})
+	annotations, _ := metadata["annotations"].(map[string]interface{})
+	_, ok := annotations[release.HookAnnotation]
+	return ok
+}
@@ -0,0 +1,65 @@
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRenderHelmChartSkipsHooks(t *testing.T) {
+	chartDir := t.TempDir()
+	files := map[string]string{
+		"Chart.yaml": `
+apiVersion: v2
+name: web
+version: 0.1.0
+`,
+		"templates/configmap.yaml": `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}-config
+`,
+		"templates/job.yaml": `
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: {{ .Release.Name }}-migrate
+  annotations:
+    helm.sh/hook: pre-install
+`,
+		"templates/tests/test-connection.yaml": `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: {{ .Release.Name }}-test-connection
+  annotations:
+    "helm.sh/hook": test
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(chartDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	manifests, err := renderHelmChart(chartDir, kubernetesConfig{})
+	if err != nil {
+		t.Fatalf("renderHelmChart() error = %v", err)
+	}
+
+	got := []string{}
+	for _, manifest := range manifests {
+		metadata, _ := manifest.Object["metadata"].(map[string]interface{})
+		got = append(got, stringValue(manifest.Object["kind"])+"/"+stringValue(metadata["name"]))
+	}
+	want := []string{"ConfigMap/web-config"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("renderHelmChart() = %v, want %v", got, want)
+	}
+}
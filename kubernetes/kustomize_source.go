@@ -0,0 +1,83 @@
+package kubernetes
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/mitchellh/go-homedir"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// buildKustomizations :: build every kustomization matched by the configured paths, the
+// same way as `kustomize build`. Each object records the overlay directory that produced it.
+func buildKustomizations(patterns []string) ([]manifestObject, error) {
+	dirs, err := resolveKustomizePaths(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	fSys := filesys.MakeFsOnDisk()
+
+	manifests := []manifestObject{}
+	for _, dir := range dirs {
+		resources, err := kustomizer.Run(fSys, dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build kustomization %s: %v", dir, err)
+		}
+
+		data, err := resources.AsYaml()
+		if err != nil {
+			return nil, err
+		}
+
+		objects, err := decodeManifests(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode kustomization %s: %v", dir, err)
+		}
+
+		for _, object := range objects {
+			manifests = append(manifests, manifestObject{Object: object, sourceInfo: sourceInfo{Path: dir, SourceType: sourceTypeKustomize}})
+		}
+	}
+
+	return manifests, nil
+}
+
+// resolveKustomizePaths :: expand the configured glob patterns into a sorted list of kustomization directories
+func resolveKustomizePaths(patterns []string) ([]string, error) {
+	seen := map[string]bool{}
+	dirs := []string{}
+
+	for _, pattern := range patterns {
+		expanded, err := homedir.Expand(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		matches, err := filepath.Glob(expanded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kustomize_paths pattern %q: %v", pattern, err)
+		}
+
+		for _, match := range matches {
+			match = filepath.Clean(match)
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if !info.IsDir() || seen[match] {
+				continue
+			}
+			seen[match] = true
+			dirs = append(dirs, match)
+		}
+	}
+	sort.Strings(dirs)
+
+	return dirs, nil
+}
@@ -0,0 +1,302 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+// sourceInfo records where a row was read from. It is embedded in the row type
+// of every table, so the source columns resolve next to the object's own fields.
+type sourceInfo struct {
+	Path         string
+	SourceType   string
+	ChartName    string
+	ChartVersion string
+}
+
+// Source types of rows which were not read from the API server
+const (
+	sourceTypeManifest  = "manifest"
+	sourceTypeHelm      = "helm"
+	sourceTypeKustomize = "kustomize"
+)
+
+// manifestObject is a Kubernetes object decoded from a manifest file
+type manifestObject struct {
+	Object map[string]interface{}
+	sourceInfo
+}
+
+// manifestRowFunc converts a manifest object into a row of a table
+type manifestRowFunc func(manifestObject) (interface{}, error)
+
+// decode :: convert the object into the typed struct of a table
+func (m manifestObject) decode(into interface{}) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(m.Object, into)
+}
+
+// isManifestSource :: tables serve objects decoded from manifest files, charts or kustomizations instead of calling the API
+func isManifestSource(d *plugin.QueryData) bool {
+	return hasManifestSources(GetConfig(d.Connection))
+}
+
+func hasManifestSources(kubernetesConfig kubernetesConfig) bool {
+	return len(kubernetesConfig.ManifestFilePaths) > 0 || len(kubernetesConfig.HelmChartDirs) > 0 || len(kubernetesConfig.KustomizePaths) > 0
+}
+
+// streamManifestObjects :: stream the manifest objects of the given kinds which match the name and namespace quals
+func streamManifestObjects(ctx context.Context, d *plugin.QueryData, newRow manifestRowFunc, groupKinds ...schema.GroupKind) error {
+	manifests, err := listManifestObjects(ctx, d, groupKinds...)
+	if err != nil {
+		return err
+	}
+
+	for _, manifest := range manifests {
+		row, err := newRow(manifest)
+		if err != nil {
+			return fmt.Errorf("%s: %v", manifest.Path, err)
+		}
+		d.StreamListItem(ctx, row)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// getManifestObject :: the first manifest object of the given kinds which matches the name and namespace quals
+func getManifestObject(ctx context.Context, d *plugin.QueryData, newRow manifestRowFunc, groupKinds ...schema.GroupKind) (interface{}, error) {
+	manifests, err := listManifestObjects(ctx, d, groupKinds...)
+	if err != nil || len(manifests) == 0 {
+		return nil, err
+	}
+
+	row, err := newRow(manifests[0])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", manifests[0].Path, err)
+	}
+
+	return row, nil
+}
+
+// listManifestObjects :: manifest objects of the given kinds which match the name and namespace quals.
+// The API version is not compared, so e.g. a batch/v1beta1 CronJob is served by the batch/v1 table.
+func listManifestObjects(ctx context.Context, d *plugin.QueryData, groupKinds ...schema.GroupKind) ([]manifestObject, error) {
+	manifests, err := getCachedManifestObjects(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	name := d.KeyColumnQualString("name")
+	namespace := d.KeyColumnQualString("namespace")
+
+	// objects of namespaced tables are subject to the connection's namespace filters
+	kubernetesConfig := GetConfig(d.Connection)
+	filterNamespaces := hasNamespaceFilter(kubernetesConfig) && hasNamespaceColumn(d.Table)
+
+	matches := []manifestObject{}
+	for _, manifest := range manifests {
+		gvk := schema.FromAPIVersionAndKind(stringValue(manifest.Object["apiVersion"]), stringValue(manifest.Object["kind"]))
+		if !containsGroupKind(groupKinds, gvk.GroupKind()) {
+			continue
+		}
+
+		metadata, _ := manifest.Object["metadata"].(map[string]interface{})
+		if name != "" && stringValue(metadata["name"]) != name {
+			continue
+		}
+		if namespace != "" && stringValue(metadata["namespace"]) != namespace {
+			continue
+		}
+		if filterNamespaces && stringValue(metadata["namespace"]) != "" {
+			allowed, err := isNamespaceAllowed(kubernetesConfig, stringValue(metadata["namespace"]))
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				continue
+			}
+		}
+
+		matches = append(matches, manifest)
+	}
+
+	return matches, nil
+}
+
+// getCachedManifestObjects :: the manifest objects of the connection, decoded once and kept in
+// the connection cache, which is cleared when the connection config changes
+func getCachedManifestObjects(ctx context.Context, d *plugin.QueryData) ([]manifestObject, error) {
+	// have we already decoded the manifests for this connection?
+	cacheKey := "getManifestObjects"
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.([]manifestObject), nil
+	}
+
+	manifests, err := getManifestObjects(ctx, d.Connection)
+	if err != nil {
+		return nil, err
+	}
+
+	// save the manifests in cache
+	d.ConnectionManager.Cache.Set(cacheKey, manifests)
+
+	return manifests, nil
+}
+
+// getManifestObjects :: decode every object in the manifest files, charts and kustomizations configured for the connection
+func getManifestObjects(ctx context.Context, connection *plugin.Connection) ([]manifestObject, error) {
+	kubernetesConfig := GetConfig(connection)
+
+	paths, err := resolveManifestFilePaths(kubernetesConfig.ManifestFilePaths)
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := []manifestObject{}
+	for _, path := range paths {
+		objects, err := decodeManifestFile(path)
+		if err != nil {
+			plugin.Logger(ctx).Error("getManifestObjects", "decode_error", err, "path", path)
+			return nil, err
+		}
+
+		for _, object := range objects {
+			manifests = append(manifests, manifestObject{Object: object, sourceInfo: sourceInfo{Path: path, SourceType: sourceTypeManifest}})
+		}
+	}
+
+	for _, chartDir := range kubernetesConfig.HelmChartDirs {
+		objects, err := renderHelmChart(chartDir, kubernetesConfig)
+		if err != nil {
+			plugin.Logger(ctx).Error("getManifestObjects", "render_error", err, "chart", chartDir)
+			return nil, err
+		}
+		manifests = append(manifests, objects...)
+	}
+
+	objects, err := buildKustomizations(kubernetesConfig.KustomizePaths)
+	if err != nil {
+		plugin.Logger(ctx).Error("getManifestObjects", "build_error", err)
+		return nil, err
+	}
+	manifests = append(manifests, objects...)
+
+	return manifests, nil
+}
+
+// resolveManifestFilePaths :: expand the configured glob patterns into a sorted list of files
+func resolveManifestFilePaths(patterns []string) ([]string, error) {
+	seen := map[string]bool{}
+	paths := []string{}
+
+	for _, pattern := range patterns {
+		expanded, err := homedir.Expand(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		matches, err := filepath.Glob(expanded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid manifest_file_paths pattern %q: %v", pattern, err)
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if info.IsDir() || seen[match] {
+				continue
+			}
+			seen[match] = true
+			paths = append(paths, match)
+		}
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// decodeManifestFile :: decode every document of a YAML or JSON file. Documents of
+// a List kind, e.g. the output of `kubectl get -o yaml`, are expanded into their items.
+func decodeManifestFile(path string) ([]map[string]interface{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return decodeManifests(file)
+}
+
+func decodeManifests(reader io.Reader) ([]map[string]interface{}, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(reader, 4096)
+
+	objects := []map[string]interface{}{}
+	for {
+		var object map[string]interface{}
+		if err := decoder.Decode(&object); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		// skip empty documents and anything which is not a Kubernetes object
+		if object == nil || stringValue(object["kind"]) == "" {
+			continue
+		}
+
+		if items, ok := object["items"].([]interface{}); ok && strings.HasSuffix(stringValue(object["kind"]), "List") {
+			for _, item := range items {
+				if itemObject, ok := item.(map[string]interface{}); ok {
+					objects = append(objects, itemObject)
+				}
+			}
+			continue
+		}
+
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+func hasNamespaceColumn(table *plugin.Table) bool {
+	for _, column := range table.Columns {
+		if column.Name == "namespace" {
+			return true
+		}
+	}
+	return false
+}
+
+func containsGroupKind(groupKinds []schema.GroupKind, groupKind schema.GroupKind) bool {
+	for _, gk := range groupKinds {
+		if gk == groupKind {
+			return true
+		}
+	}
+	return false
+}
+
+func stringValue(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
@@ -0,0 +1,186 @@
+package kubernetes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeManifests(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		// kind/name of each decoded object, in order
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "single document",
+			manifest: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+`,
+			want: []string{"ConfigMap/settings"},
+		},
+		{
+			name: "multiple documents",
+			manifest: `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: web
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: frontend
+  namespace: web
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: frontend
+  namespace: web
+`,
+			want: []string{"Namespace/web", "Deployment/frontend", "Service/frontend"},
+		},
+		{
+			name: "empty documents and comments are skipped",
+			manifest: `
+---
+# generated by a template with nothing to render
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: token
+---
+`,
+			want: []string{"Secret/token"},
+		},
+		{
+			name: "documents without a kind are skipped",
+			manifest: `
+replicas: 3
+image: nginx
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: builder
+`,
+			want: []string{"ServiceAccount/builder"},
+		},
+		{
+			name: "list kinds are flattened",
+			manifest: `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: first
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: second
+`,
+			want: []string{"ConfigMap/first", "ConfigMap/second"},
+		},
+		{
+			name: "typed list kinds are flattened",
+			manifest: `
+apiVersion: v1
+kind: PodList
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: web-0
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: web-1
+`,
+			want: []string{"Pod/web-0", "Pod/web-1"},
+		},
+		{
+			name: "empty list",
+			manifest: `
+apiVersion: v1
+kind: List
+items: []
+`,
+			want: []string{},
+		},
+		{
+			name: "unknown kinds are kept for custom resource tables",
+			manifest: `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gadget
+spec:
+  size: 3
+`,
+			want: []string{"Widget/gadget"},
+		},
+		{
+			name: "kinds ending in List without items are kept",
+			manifest: `
+apiVersion: example.com/v1
+kind: AllowList
+metadata:
+  name: registries
+`,
+			want: []string{"AllowList/registries"},
+		},
+		{
+			name:     "json",
+			manifest: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "settings"}}`,
+			want:     []string{"ConfigMap/settings"},
+		},
+		{
+			name:     "empty file",
+			manifest: "",
+			want:     []string{},
+		},
+		{
+			name: "invalid yaml",
+			manifest: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: [unterminated
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			objects, err := decodeManifests(strings.NewReader(test.manifest))
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("decodeManifests() returned %d objects, want an error", len(objects))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeManifests() error = %v", err)
+			}
+
+			got := []string{}
+			for _, object := range objects {
+				metadata, _ := object["metadata"].(map[string]interface{})
+				got = append(got, stringValue(object["kind"])+"/"+stringValue(metadata["name"]))
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("decodeManifests() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+// matrixKeyContext is the matrix item key holding the kubeconfig context name. It
+// matches the context_name column, so `where context_name = '...'` prunes the
+// matrix before any API call is made.
+const matrixKeyContext = "context_name"
+
+// BuildContextList :: return a list of matrix items, one per kubeconfig context the connection queries.
+// If the contexts cannot be resolved, e.g. because no context matches config_contexts, the
+// matrix is empty and the error is returned by the table's hydrate when it creates a client.
+func BuildContextList(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
+	// have we already resolved the contexts for this connection?
+	cacheKey := "BuildContextList"
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.([]map[string]interface{})
+	}
+
+	contexts, err := getConfiguredContexts(ctx, d.Connection)
+	if err != nil {
+		plugin.Logger(ctx).Error("BuildContextList", "context_error", err)
+		return []map[string]interface{}{}
+	}
+
+	matrix := make([]map[string]interface{}, len(contexts))
+	for i, contextName := range contexts {
+		matrix[i] = map[string]interface{}{matrixKeyContext: contextName}
+	}
+
+	// save the matrix in cache
+	d.ConnectionManager.Cache.Set(cacheKey, matrix)
+
+	return matrix
+}
+
+// getConfiguredContexts :: resolve the kubeconfig contexts queried by the connection.
+// `config_contexts` patterns take precedence over `config_context`, which in turn
+// takes precedence over the current context of the kubeconfig. A connection with
+// inline credentials, manifest files, charts or kustomizations has no contexts.
+func getConfiguredContexts(ctx context.Context, connection *plugin.Connection) ([]string, error) {
+	// get kubernetes config info
+	kubernetesConfig := GetConfig(connection)
+
+	// Manifest sources and inline credentials have no kubeconfig context
+	if hasManifestSources(kubernetesConfig) || kubernetesConfig.Host != nil {
+		return []string{""}, nil
+	}
+
+	if len(kubernetesConfig.ConfigContexts) == 0 && kubernetesConfig.ConfigContext != nil {
+		return []string{*kubernetesConfig.ConfigContext}, nil
+	}
+
+	kubeconfig, err := getK8ConfigForContext(ctx, connection, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(kubernetesConfig.ConfigContexts) == 0 {
+		// An empty context means no kubeconfig was found, in which case the
+		// client falls back to the in-cluster config
+		rawConfig, _ := kubeconfig.RawConfig()
+		return []string{rawConfig.CurrentContext}, nil
+	}
+
+	rawConfig, err := kubeconfig.RawConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(rawConfig.Contexts))
+	for name := range rawConfig.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	contexts := []string{}
+	for _, name := range names {
+		for _, pattern := range kubernetesConfig.ConfigContexts {
+			ok, err := path.Match(pattern, name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid config_contexts pattern %q: %v", pattern, err)
+			}
+			if ok {
+				contexts = append(contexts, name)
+				break
+			}
+		}
+	}
+
+	if len(contexts) == 0 {
+		return nil, fmt.Errorf("no kubeconfig contexts match config_contexts %q", kubernetesConfig.ConfigContexts)
+	}
+
+	return contexts, nil
+}
+
+// getContextName :: kubeconfig context for the current matrix item, or the
+// connection's configured context when called outside of a matrix fetch
+func getContextName(ctx context.Context, d *plugin.QueryData) string {
+	if matrixItem := plugin.GetMatrixItem(ctx); matrixItem != nil {
+		if contextName, ok := matrixItem[matrixKeyContext].(string); ok && contextName != "" {
+			return contextName
+		}
+	}
+
+	kubernetesConfig := GetConfig(d.Connection)
+	if kubernetesConfig.ConfigContext != nil {
+		return *kubernetesConfig.ConfigContext
+	}
+
+	return ""
+}
+
+// shouldIgnoreErrorPluginDefault :: when a connection fans out across several
+// contexts, a cluster matched by a config_contexts pattern that is unreachable or
+// denies access is logged and skipped so the remaining contexts still return rows.
+// Errors from contexts named explicitly in config_contexts are returned.
+func shouldIgnoreErrorPluginDefault() plugin.ErrorPredicateWithContext {
+	return func(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData, err error) bool {
+		contextName := getContextName(ctx, d)
+		if !isSkippableContextError(GetConfig(d.Connection), contextName, err) {
+			return false
+		}
+
+		plugin.Logger(ctx).Warn("skipping unavailable context", "context", contextName, "error", err)
+		return true
+	}
+}
+
+// isSkippableContextError :: whether an error from a context is skipped rather than
+// failing the query: the context is only matched by a pattern of config_contexts,
+// and is unreachable or denies access
+func isSkippableContextError(kubernetesConfig kubernetesConfig, contextName string, err error) bool {
+	return isPatternMatchedContext(kubernetesConfig, contextName) && isContextUnavailableError(err)
+}
+
+// isPatternMatchedContext :: whether a context is matched by a glob pattern of
+// config_contexts, and not named explicitly
+func isPatternMatchedContext(kubernetesConfig kubernetesConfig, contextName string) bool {
+	matched := false
+	for _, pattern := range kubernetesConfig.ConfigContexts {
+		if pattern == contextName {
+			return false
+		}
+		if strings.ContainsAny(pattern, `*?[\`) {
+			if ok, _ := path.Match(pattern, contextName); ok {
+				matched = true
+			}
+		}
+	}
+	return matched
+}
+
+func isContextUnavailableError(err error) bool {
+	if apierrors.IsUnauthorized(err) || apierrors.IsForbidden(err) || apierrors.IsServiceUnavailable(err) {
+		return true
+	}
+
+	// connection refused, DNS failures, dial and TLS handshake timeouts
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,114 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/context_key"
+)
+
+func TestIsSkippableContextError(t *testing.T) {
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("access denied"))
+	unreachable := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		name           string
+		configContexts []string
+		contextName    string
+		err            error
+		want           bool
+	}{
+		{name: "unreachable context matched by a pattern", configContexts: []string{"prod-*"}, contextName: "prod-eu", err: unreachable, want: true},
+		{name: "forbidden context matched by a pattern", configContexts: []string{"prod-?u"}, contextName: "prod-eu", err: forbidden, want: true},
+		{name: "unreachable context named explicitly", configContexts: []string{"prod-eu"}, contextName: "prod-eu", err: unreachable, want: false},
+		{name: "context named explicitly and matched by a pattern", configContexts: []string{"prod-*", "prod-eu"}, contextName: "prod-eu", err: unreachable, want: false},
+		{name: "other context named explicitly", configContexts: []string{"prod-*", "staging"}, contextName: "prod-eu", err: forbidden, want: true},
+		{name: "other errors are returned", configContexts: []string{"prod-*"}, contextName: "prod-eu", err: errors.New("invalid namespaces pattern"), want: false},
+		{name: "single context", contextName: "prod-eu", err: unreachable, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubernetesConfig := kubernetesConfig{ConfigContexts: test.configContexts}
+			if got := isSkippableContextError(kubernetesConfig, test.contextName, test.err); got != test.want {
+				t.Errorf("isSkippableContextError() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetQueryNamespace(t *testing.T) {
+	filtered := kubernetesConfig{ConfigContexts: []string{"prod-*"}, Namespaces: []string{"team-*"}, ExcludeNamespaces: []string{"team-secret"}}
+
+	tests := []struct {
+		name       string
+		config     kubernetesConfig
+		matrixItem map[string]interface{}
+		namespace  string
+		want       string
+		wantOk     bool
+		wantErr    bool
+	}{
+		{name: "no namespace filter", config: kubernetesConfig{}, namespace: "kube-system", want: "kube-system", wantOk: true},
+		{name: "no namespace filter or qual", config: kubernetesConfig{}, want: "", wantOk: true},
+		{
+			name:       "allowed namespace",
+			config:     filtered,
+			matrixItem: map[string]interface{}{matrixKeyContext: "prod-eu", matrixKeyNamespace: "team-web"},
+			namespace:  "team-web",
+			want:       "team-web",
+			wantOk:     true,
+		},
+		{
+			name:       "excluded namespace",
+			config:     filtered,
+			matrixItem: map[string]interface{}{matrixKeyContext: "prod-eu", matrixKeyNamespace: "team-secret"},
+			namespace:  "team-secret",
+			want:       "team-secret",
+			wantOk:     false,
+		},
+		{
+			name:       "namespaces of the context could not be resolved",
+			config:     filtered,
+			matrixItem: map[string]interface{}{matrixKeyContext: "prod-eu", matrixKeyNamespace: "", matrixKeyNamespaceError: "namespaces is forbidden"},
+			wantErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			if test.matrixItem != nil {
+				ctx = context.WithValue(ctx, context_key.MatrixItem, test.matrixItem)
+			}
+			d := &plugin.QueryData{
+				Connection:     &plugin.Connection{Name: "kubernetes", Config: test.config},
+				KeyColumnQuals: map[string]*proto.QualValue{},
+			}
+			if test.namespace != "" {
+				d.KeyColumnQuals["namespace"] = &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: test.namespace}}
+			}
+
+			got, ok, err := getQueryNamespace(ctx, d)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("getQueryNamespace() = %q, %v, want an error", got, ok)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getQueryNamespace() error = %v", err)
+			}
+			if ok != test.wantOk || (ok && got != test.want) {
+				t.Errorf("getQueryNamespace() = %q, %v, want %q, %v", got, ok, test.want, test.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+// maxConcurrentNamespaceLists limits the namespaces listed at once by listNamespaces
+const maxConcurrentNamespaceLists = 10
+
+// listAccessibleNamespaces :: called by a namespaced table when its cluster-wide list was
+// forbidden. The list function is run again for each namespace the caller may be able to
+// access. As the SDK has no way to return a warning with the rows of a query, namespaces
+// where listing is also forbidden fail the query with an error naming them, so a result
+// never silently leaves them out. The original error is returned if no namespace could be
+// listed.
+func listAccessibleNamespaces(ctx context.Context, d *plugin.QueryData, listFunc plugin.HydrateFunc, forbiddenErr error) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	contextName := getContextName(ctx, d)
+
+	namespaces, err := getAccessibleNamespaces(ctx, d, contextName)
+	if err != nil {
+		logger.Error("listAccessibleNamespaces", "namespace_error", err)
+		return nil, forbiddenErr
+	}
+	logger.Debug("listAccessibleNamespaces", "table", d.Table.Name, "context", contextName, "namespaces", namespaces)
+
+	var listErr error
+	skipped := []string{}
+	listed := 0
+
+	for i, err := range listNamespaces(ctx, d, listFunc, namespaces) {
+		switch {
+		case err == nil:
+			listed++
+		case apierrors.IsForbidden(err):
+			skipped = append(skipped, namespaces[i])
+		case listErr == nil:
+			listErr = err
+		}
+	}
+
+	if listErr != nil {
+		return nil, listErr
+	}
+	if listed == 0 {
+		return nil, forbiddenErr
+	}
+
+	if len(skipped) > 0 {
+		sort.Strings(skipped)
+		logger.Warn("namespaces the caller cannot list", "table", d.Table.Name, "context", contextName, "namespaces", strings.Join(skipped, ","))
+		return nil, fmt.Errorf("%s is only partially listed in context %q, the caller cannot list namespaces %s: add them to exclude_namespaces to query the others", d.Table.Name, contextName, strings.Join(skipped, ", "))
+	}
+
+	return nil, nil
+}
+
+// listNamespaces :: run the list function for each namespace, as if the query had
+// `where namespace = '...'`, listing up to maxConcurrentNamespaceLists namespaces at once.
+// The errors are returned in the order of the namespaces.
+func listNamespaces(ctx context.Context, d *plugin.QueryData, listFunc plugin.HydrateFunc, namespaces []string) []error {
+	errs := make([]error, len(namespaces))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentNamespaceLists)
+	for i, namespace := range namespaces {
+		wg.Add(1)
+		go func(i int, namespace string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			namespaceQueryData := d.ShallowCopy()
+			namespaceQueryData.KeyColumnQuals["namespace"] = &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: namespace}}
+
+			_, errs[i] = listFunc(ctx, namespaceQueryData, nil)
+		}(i, namespace)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// getAccessibleNamespaces :: namespaces the caller may be able to list resources in. All
+// namespaces are used if they can be listed. Otherwise the candidates are the exact names
+// of the connection's `namespaces`, or, without any, the namespace of the kubeconfig context
+// and any namespaces a SelfSubjectRulesReview shows the caller is granted by name.
+func getAccessibleNamespaces(ctx context.Context, d *plugin.QueryData, contextName string) ([]string, error) {
+	// have we already resolved the namespaces for this context?
+	cacheKey := "getAccessibleNamespaces-" + contextName
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.([]string), nil
+	}
+
+	namespaces, err := listNamespaceNames(ctx, d, contextName)
+	if err != nil {
+		if !apierrors.IsForbidden(err) {
+			return nil, err
+		}
+
+		seen := map[string]bool{}
+		namespaces = []string{}
+		addNamespace := func(namespace string) {
+			if namespace != "" && !seen[namespace] {
+				seen[namespace] = true
+				namespaces = append(namespaces, namespace)
+			}
+		}
+
+		// the configured namespaces are the best guess of what the caller can access
+		for _, namespace := range GetConfig(d.Connection).Namespaces {
+			if !hasNamespacePatterns([]string{namespace}) {
+				addNamespace(namespace)
+			}
+		}
+		if len(namespaces) == 0 {
+			defaultNamespace := getContextNamespace(ctx, d, contextName)
+			addNamespace(defaultNamespace)
+
+			clientset, err := getNewClientsetForContext(ctx, d.Connection, contextName)
+			if err != nil {
+				return nil, err
+			}
+
+			review, err := clientset.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, &authorizationv1.SelfSubjectRulesReview{
+				Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: defaultNamespace},
+			}, metav1.CreateOptions{})
+			if err != nil {
+				plugin.Logger(ctx).Warn("getAccessibleNamespaces", "rules_review_error", err)
+			} else {
+				for _, rule := range review.Status.ResourceRules {
+					if grantsNamespaceAccess(rule) {
+						for _, name := range rule.ResourceNames {
+							addNamespace(name)
+						}
+					}
+				}
+			}
+		}
+		sort.Strings(namespaces)
+	}
+
+	// save the namespaces in cache
+	d.ConnectionManager.Cache.Set(cacheKey, namespaces)
+
+	return namespaces, nil
+}
+
+// listNamespaceNames :: names of every namespace in the cluster of the context
+func listNamespaceNames(ctx context.Context, d *plugin.QueryData, contextName string) ([]string, error) {
+	clientset, err := getNewClientsetForContext(ctx, d.Connection, contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	namespaces := []string{}
+	pageLeft := true
+	for pageLeft {
+		response, err := clientset.CoreV1().Namespaces().List(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+		} else {
+			pageLeft = false
+		}
+
+		for _, namespace := range response.Items {
+			namespaces = append(namespaces, namespace.Name)
+		}
+	}
+
+	return namespaces, nil
+}
+
+// getContextNamespace :: namespace of the kubeconfig context, "default" if it has none
+func getContextNamespace(ctx context.Context, d *plugin.QueryData, contextName string) string {
+	if GetConfig(d.Connection).Host == nil {
+		if kubeconfig, err := getK8ConfigForContext(ctx, d.Connection, contextName); err == nil {
+			if namespace, _, err := kubeconfig.Namespace(); err == nil && namespace != "" {
+				return namespace
+			}
+		}
+	}
+	return "default"
+}
+
+// grantsNamespaceAccess :: whether a rule grants get on namespaces, e.g. a role binding
+// giving access to named namespaces
+func grantsNamespaceAccess(rule authorizationv1.ResourceRule) bool {
+	return containsAny(rule.APIGroups, "", "*") &&
+		containsAny(rule.Resources, "namespaces", "*") &&
+		containsAny(rule.Verbs, "get", "*")
+}
+
+func containsAny(values []string, targets ...string) bool {
+	for _, value := range values {
+		for _, target := range targets {
+			if value == target {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isClusterListForbidden :: whether a namespaced table should fall back to listing
+// namespaces one by one, i.e. the first page of a cluster-wide list was forbidden
+func isClusterListForbidden(err error, namespace string, input metav1.ListOptions) bool {
+	return namespace == "" && input.Continue == "" && apierrors.IsForbidden(err)
+}
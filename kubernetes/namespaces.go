@@ -0,0 +1,193 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+// matrixKeyNamespace is the matrix item key holding the namespace a namespaced table
+// is listed in. It matches the namespace column, so `where namespace = '...'` prunes
+// the matrix before any API call is made.
+const matrixKeyNamespace = "namespace"
+
+// matrixKeyNamespaceError is the matrix item key holding the error resolving the namespaces
+// of a context. It is not a column; the error is returned when the item is queried.
+const matrixKeyNamespaceError = "namespace_error"
+
+// BuildContextNamespaceList :: return a list of matrix items for namespaced tables. When
+// the connection restricts namespaces, there is one item per context and allowed
+// namespace, so tables list each namespace in parallel instead of cluster-wide.
+func BuildContextNamespaceList(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
+	contexts := BuildContextList(ctx, d)
+
+	kubernetesConfig := GetConfig(d.Connection)
+	if len(contexts) == 0 || !hasNamespaceFilter(kubernetesConfig) || hasManifestSources(kubernetesConfig) {
+		return contexts
+	}
+
+	// have we already resolved the namespaces for this connection?
+	cacheKey := "BuildContextNamespaceList"
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.([]map[string]interface{})
+	}
+
+	matrix := []map[string]interface{}{}
+	failed := false
+	for _, item := range contexts {
+		contextName := item[matrixKeyContext].(string)
+
+		namespaces, err := getAllowedNamespaces(ctx, d, contextName)
+		if err != nil {
+			// as for tables, an unavailable context matched by a pattern is skipped
+			if isSkippableContextError(kubernetesConfig, contextName, err) {
+				plugin.Logger(ctx).Warn("skipping unavailable context", "context", contextName, "error", err)
+				continue
+			}
+
+			// other errors, e.g. an invalid namespaces pattern, are returned by the
+			// table's hydrate for the context, see getQueryNamespace
+			plugin.Logger(ctx).Error("BuildContextNamespaceList", "namespace_error", err, "context", contextName)
+			failed = true
+			matrix = append(matrix, map[string]interface{}{
+				matrixKeyContext:        contextName,
+				matrixKeyNamespace:      "",
+				matrixKeyNamespaceError: err.Error(),
+			})
+			continue
+		}
+
+		for _, namespace := range namespaces {
+			matrix = append(matrix, map[string]interface{}{
+				matrixKeyContext:   contextName,
+				matrixKeyNamespace: namespace,
+			})
+		}
+	}
+
+	// save the matrix in cache, unless a context failed and should be retried by the next query
+	if !failed {
+		d.ConnectionManager.Cache.Set(cacheKey, matrix)
+	}
+
+	return matrix
+}
+
+// getQueryNamespace :: namespace a namespaced table is queried in, or "" for all
+// namespaces. ok is false when the connection restricts namespaces and the namespace
+// is not allowed, e.g. because no namespace matched and the matrix is empty. An error
+// is returned when the namespaces of the context could not be resolved.
+func getQueryNamespace(ctx context.Context, d *plugin.QueryData) (namespace string, ok bool, err error) {
+	namespace = d.KeyColumnQualString("namespace")
+
+	kubernetesConfig := GetConfig(d.Connection)
+	if !hasNamespaceFilter(kubernetesConfig) {
+		return namespace, true, nil
+	}
+
+	matrixItem := plugin.GetMatrixItem(ctx)
+	if matrixItem == nil {
+		// the matrix is also empty when config_contexts cannot be resolved
+		if len(kubernetesConfig.ConfigContexts) > 0 && !hasManifestSources(kubernetesConfig) {
+			if _, err := getConfiguredContexts(ctx, d.Connection); err != nil {
+				return "", false, err
+			}
+		}
+	} else if namespaceError, ok := matrixItem[matrixKeyNamespaceError].(string); ok {
+		return "", false, fmt.Errorf("context %q: %s", getContextName(ctx, d), namespaceError)
+	}
+
+	if namespace == "" {
+		return "", false, nil
+	}
+
+	// invalid patterns are logged when the matrix is built
+	allowed, _ := isNamespaceAllowed(kubernetesConfig, namespace)
+	return namespace, allowed, nil
+}
+
+// getAllowedNamespaces :: namespaces of the context matching the connection's namespace
+// filters. When `namespaces` only holds exact names they are used as is, so users
+// without permission to list namespaces can still query them.
+func getAllowedNamespaces(ctx context.Context, d *plugin.QueryData, contextName string) ([]string, error) {
+	kubernetesConfig := GetConfig(d.Connection)
+
+	candidates := kubernetesConfig.Namespaces
+	if len(candidates) == 0 || hasNamespacePatterns(candidates) {
+		// if namespaces cannot be listed, patterns are matched against the namespaces
+		// discovered as for a forbidden cluster-wide list
+		accessible, err := getAccessibleNamespaces(ctx, d, contextName)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append([]string{}, accessible...)
+		for _, namespace := range kubernetesConfig.Namespaces {
+			if !hasNamespacePatterns([]string{namespace}) {
+				candidates = append(candidates, namespace)
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	namespaces := []string{}
+	for _, namespace := range candidates {
+		allowed, err := isNamespaceAllowed(kubernetesConfig, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if allowed && !seen[namespace] {
+			seen[namespace] = true
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+
+	return namespaces, nil
+}
+
+// isNamespaceAllowed :: whether a namespace matches `namespaces` (when set) and none of `exclude_namespaces`
+func isNamespaceAllowed(kubernetesConfig kubernetesConfig, namespace string) (bool, error) {
+	if len(kubernetesConfig.Namespaces) > 0 {
+		ok, err := matchNamespace(kubernetesConfig.Namespaces, namespace, "namespaces")
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	excluded, err := matchNamespace(kubernetesConfig.ExcludeNamespaces, namespace, "exclude_namespaces")
+	if err != nil {
+		return false, err
+	}
+
+	return !excluded, nil
+}
+
+func matchNamespace(patterns []string, namespace string, argument string) (bool, error) {
+	for _, pattern := range patterns {
+		ok, err := path.Match(pattern, namespace)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s pattern %q: %v", argument, pattern, err)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func hasNamespaceFilter(kubernetesConfig kubernetesConfig) bool {
+	return len(kubernetesConfig.Namespaces) > 0 || len(kubernetesConfig.ExcludeNamespaces) > 0
+}
+
+func hasNamespacePatterns(patterns []string) bool {
+	for _, pattern := range patterns {
+		if strings.ContainsAny(pattern, `*?[\`) {
+			return true
+		}
+	}
+	return false
+}
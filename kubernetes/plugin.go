@@ -23,45 +23,90 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 		// DefaultGetConfig: &plugin.GetConfig{
 		// 	ShouldIgnoreError: isNotFoundError([]string{"ResourceNotFoundException", "NoSuchEntity"}),
 		// },
+		DefaultIgnoreConfig: &plugin.IgnoreConfig{
+			ShouldIgnoreErrorFunc: shouldIgnoreErrorPluginDefault(),
+		},
 		ConnectionConfigSchema: &plugin.ConnectionConfigSchema{
 			NewInstance: ConfigInstance,
 			Schema:      ConfigSchema,
 		},
-		TableMap: map[string]*plugin.Table{
-			"kubernetes_cluster_role":               tableKubernetesClusterRole(ctx),
-			"kubernetes_cluster_role_binding":       tableKubernetesClusterRoleBinding(ctx),
-			"kubernetes_config_map":                 tableKubernetesConfigMap(ctx),
-			"kubernetes_cronjob":                    tableKubernetesCronJob(ctx),
-			"kubernetes_custom_resource_definition": tableKubernetesCustomResourceDefinition(ctx),
-			"kubernetes_daemonset":                  tableKubernetesDaemonset(ctx),
-			"kubernetes_deployment":                 tableKubernetesDeployment(ctx),
-			"kubernetes_endpoint":                   tableKubernetesEndpoints(ctx),
-			"kubernetes_endpoint_slice":             tableKubernetesEndpointSlice(ctx),
-			"kubernetes_horizontal_pod_autoscaler":  tableKubernetesHorizontalPodAutoscaler(ctx),
-			"kubernetes_ingress":                    tableKubernetesIngress(ctx),
-			"kubernetes_job":                        tableKubernetesJob(ctx),
-			"kubernetes_limit_range":                tableKubernetesLimitRange(ctx),
-			"kubernetes_namespace":                  tableKubernetesNamespace(ctx),
-			"kubernetes_network_policy":             tableKubernetesNetworkPolicy(ctx),
-			"kubernetes_node":                       tableKubernetesNode(ctx),
-			"kubernetes_persistent_volume":          tableKubernetesPersistentVolume(ctx),
-			"kubernetes_persistent_volume_claim":    tableKubernetesPersistentVolumeClaim(ctx),
-			"kubernetes_pod":                        tableKubernetesPod(ctx),
-			"kubernetes_pod_disruption_budget":      tableKubernetesPDB(ctx),
-			"kubernetes_pod_security_policy":        tableKubernetesPodSecurityPolicy(ctx),
-			"kubernetes_replicaset":                 tableKubernetesReplicaSet(ctx),
-			"kubernetes_replication_controller":     tableKubernetesReplicaController(ctx),
-			"kubernetes_resource_quota":             tableKubernetesResourceQuota(ctx),
-			"kubernetes_role":                       tableKubernetesRole(ctx),
-			"kubernetes_role_binding":               tableKubernetesRoleBinding(ctx),
-			"kubernetes_secret":                     tableKubernetesSecret(ctx),
-			"kubernetes_service":                    tableKubernetesService(ctx),
-			"kubernetes_service_account":            tableKubernetesServiceAccount(ctx),
-			"kubernetes_stateful_set":               tableKubernetesStatefulSet(ctx),
-
-			// "kubernetes_pod_template_spec":    tableKubernetesPodTemplateSpec(ctx),
-		},
+		ConnectionConfigChangedFunc: connectionConfigChanged,
+		SchemaMode:                  plugin.SchemaModeDynamic,
+		TableMapFunc:                pluginTableDefinitions,
 	}
 
 	return p
 }
+
+// pluginTableDefinitions :: the built-in tables, plus a table for each custom resource
+// definition of the connection. The built-in tables are still served if the definitions
+// cannot be listed.
+func pluginTableDefinitions(ctx context.Context, connection *plugin.Connection) (map[string]*plugin.Table, error) {
+	tables := map[string]*plugin.Table{
+		"kubernetes_api_resource":                     tableKubernetesAPIResource(ctx),
+		"kubernetes_api_service":                      tableKubernetesAPIService(ctx),
+		"kubernetes_certificate_signing_request":      tableKubernetesCertificateSigningRequest(ctx),
+		"kubernetes_cluster":                          tableKubernetesCluster(ctx),
+		"kubernetes_cluster_role":                     tableKubernetesClusterRole(ctx),
+		"kubernetes_cluster_role_binding":             tableKubernetesClusterRoleBinding(ctx),
+		"kubernetes_config_map":                       tableKubernetesConfigMap(ctx),
+		"kubernetes_container":                        tableKubernetesContainer(ctx),
+		"kubernetes_container_status":                 tableKubernetesContainerStatus(ctx),
+		"kubernetes_cronjob":                          tableKubernetesCronJob(ctx),
+		"kubernetes_csi_driver":                       tableKubernetesCSIDriver(ctx),
+		"kubernetes_csi_node":                         tableKubernetesCSINode(ctx),
+		"kubernetes_custom_resource_definition":       tableKubernetesCustomResourceDefinition(ctx),
+		"kubernetes_daemonset":                        tableKubernetesDaemonset(ctx),
+		"kubernetes_deployment":                       tableKubernetesDeployment(ctx),
+		"kubernetes_endpoint":                         tableKubernetesEndpoints(ctx),
+		"kubernetes_endpoint_slice":                   tableKubernetesEndpointSlice(ctx),
+		"kubernetes_event":                            tableKubernetesEvent(ctx),
+		"kubernetes_horizontal_pod_autoscaler":        tableKubernetesHorizontalPodAutoscaler(ctx),
+		"kubernetes_ingress":                          tableKubernetesIngress(ctx),
+		"kubernetes_ingress_class":                    tableKubernetesIngressClass(ctx),
+		"kubernetes_job":                              tableKubernetesJob(ctx),
+		"kubernetes_lease":                            tableKubernetesLease(ctx),
+		"kubernetes_limit_range":                      tableKubernetesLimitRange(ctx),
+		"kubernetes_mutating_webhook_configuration":   tableKubernetesMutatingWebhookConfiguration(ctx),
+		"kubernetes_namespace":                        tableKubernetesNamespace(ctx),
+		"kubernetes_network_policy":                   tableKubernetesNetworkPolicy(ctx),
+		"kubernetes_node":                             tableKubernetesNode(ctx),
+		"kubernetes_node_metric":                      tableKubernetesNodeMetric(ctx),
+		"kubernetes_persistent_volume":                tableKubernetesPersistentVolume(ctx),
+		"kubernetes_persistent_volume_claim":          tableKubernetesPersistentVolumeClaim(ctx),
+		"kubernetes_pod":                              tableKubernetesPod(ctx),
+		"kubernetes_pod_disruption_budget":            tableKubernetesPDB(ctx),
+		"kubernetes_pod_log":                          tableKubernetesPodLog(ctx),
+		"kubernetes_pod_metric":                       tableKubernetesPodMetric(ctx),
+		"kubernetes_pod_security_policy":              tableKubernetesPodSecurityPolicy(ctx),
+		"kubernetes_priority_class":                   tableKubernetesPriorityClass(ctx),
+		"kubernetes_replicaset":                       tableKubernetesReplicaSet(ctx),
+		"kubernetes_replication_controller":           tableKubernetesReplicaController(ctx),
+		"kubernetes_resource":                         tableKubernetesResource(ctx),
+		"kubernetes_resource_quota":                   tableKubernetesResourceQuota(ctx),
+		"kubernetes_role":                             tableKubernetesRole(ctx),
+		"kubernetes_role_binding":                     tableKubernetesRoleBinding(ctx),
+		"kubernetes_runtime_class":                    tableKubernetesRuntimeClass(ctx),
+		"kubernetes_secret":                           tableKubernetesSecret(ctx),
+		"kubernetes_service":                          tableKubernetesService(ctx),
+		"kubernetes_service_account":                  tableKubernetesServiceAccount(ctx),
+		"kubernetes_stateful_set":                     tableKubernetesStatefulSet(ctx),
+		"kubernetes_storage_class":                    tableKubernetesStorageClass(ctx),
+		"kubernetes_validating_webhook_configuration": tableKubernetesValidatingWebhookConfiguration(ctx),
+		"kubernetes_volume_attachment":                tableKubernetesVolumeAttachment(ctx),
+
+		// "kubernetes_pod_template_spec":    tableKubernetesPodTemplateSpec(ctx),
+	}
+
+	crdTables, err := getCustomResourceTables(ctx, connection, tables)
+	if err != nil {
+		plugin.Logger(ctx).Warn("pluginTableDefinitions", "custom_resource_tables_error", err)
+		return tables, nil
+	}
+
+	for _, crdTable := range crdTables {
+		tables[crdTable.Name] = tableKubernetesCustomResource(ctx, crdTable)
+	}
+
+	return tables, nil
+}
@@ -0,0 +1,220 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// podInfo is embedded in the rows of tables derived from pods, e.g. one row per container
+type podInfo struct {
+	PodName   string
+	PodUID    types.UID
+	Namespace string
+	NodeName  string
+	Phase     v1.PodPhase
+}
+
+func newPodInfo(pod v1.Pod) podInfo {
+	return podInfo{
+		PodName:   pod.Name,
+		PodUID:    pod.UID,
+		Namespace: pod.Namespace,
+		NodeName:  pod.Spec.NodeName,
+		Phase:     pod.Status.Phase,
+	}
+}
+
+// podObjectFunc streams the rows of a table derived from a pod. The unstructured object
+// holds fields which are newer than the typed pod, e.g. the restart policy of sidecars.
+type podObjectFunc func(ctx context.Context, d *plugin.QueryData, pod v1.Pod, object map[string]interface{}, source sourceInfo) error
+
+// podObjectColumns :: columns of the pod a row of a table derived from pods belongs to
+func podObjectColumns() []*plugin.Column {
+	return []*plugin.Column{
+		{
+			Name:        "pod_name",
+			Type:        proto.ColumnType_STRING,
+			Description: "Name of the pod.",
+		},
+		{
+			Name:        "namespace",
+			Type:        proto.ColumnType_STRING,
+			Description: "Namespace of the pod.",
+		},
+		{
+			Name:        "pod_uid",
+			Type:        proto.ColumnType_STRING,
+			Description: "UID of the pod.",
+			Transform:   transform.FromField("PodUID"),
+		},
+		{
+			Name:        "node_name",
+			Type:        proto.ColumnType_STRING,
+			Description: "Name of the node the pod is scheduled to.",
+		},
+		{
+			Name:        "phase",
+			Type:        proto.ColumnType_STRING,
+			Description: "Phase of the pod, one of Pending, Running, Succeeded, Failed or Unknown.",
+		},
+		{
+			Name:        "selector_search",
+			Type:        proto.ColumnType_STRING,
+			Description: "A label selector string to restrict the list of returned pods by their labels.",
+			Transform:   transform.FromQual("selector_search"),
+		},
+	}
+}
+
+// podKeyColumns :: quals of tables derived from pods which are pushed down to the pod list
+func podKeyColumns() []*plugin.KeyColumn {
+	return []*plugin.KeyColumn{
+		{Name: "selector_search", Require: plugin.Optional, CacheMatch: "exact"},
+		{Name: "pod_name", Require: plugin.Optional},  // metadata.name
+		{Name: "node_name", Require: plugin.Optional}, // spec.nodeName
+		{Name: "phase", Require: plugin.Optional},     // status.phase
+		{Name: "namespace", Require: plugin.Optional},
+	}
+}
+
+// listPodObjects :: list the pods matching the pod quals and pass each one to podFunc.
+// Pods are listed with the dynamic client, so fields unknown to the typed pod are kept.
+func listPodObjects(ctx context.Context, d *plugin.QueryData, listFunc plugin.HydrateFunc, podFunc podObjectFunc) (interface{}, error) {
+	if isManifestSource(d) {
+		return nil, streamManifestPodObjects(ctx, d, podFunc)
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	client, err := GetNewClientDynamic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	if d.KeyColumnQuals["selector_search"] != nil {
+		input.LabelSelector = d.KeyColumnQuals["selector_search"].GetStringValue()
+	}
+
+	fieldSelectors := buildKubernetesPodObjectFieldSelectorFilter(ctx, d)
+	if len(fieldSelectors) > 0 {
+		input.FieldSelector = strings.Join(fieldSelectors, ",")
+	}
+
+	pods := client.Resource(v1.SchemeGroupVersion.WithResource("pods"))
+
+	pageLeft := true
+	for pageLeft {
+		response, err := pods.Namespace(namespace).List(ctx, input)
+		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listFunc, err)
+			}
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.GetContinue()
+		} else {
+			pageLeft = false
+		}
+
+		for _, item := range response.Items {
+			var pod v1.Pod
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &pod); err != nil {
+				return nil, err
+			}
+
+			if err := podFunc(ctx, d, pod, item.Object, sourceInfo{}); err != nil {
+				return nil, err
+			}
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// streamManifestPodObjects :: pass the manifest pods matching the pod name qual to podFunc
+func streamManifestPodObjects(ctx context.Context, d *plugin.QueryData, podFunc podObjectFunc) error {
+	manifests, err := listManifestObjects(ctx, d, schema.GroupKind{Kind: "Pod"})
+	if err != nil {
+		return err
+	}
+
+	podName := d.KeyColumnQualString("pod_name")
+
+	for _, manifest := range manifests {
+		var pod v1.Pod
+		if err := manifest.decode(&pod); err != nil {
+			return fmt.Errorf("%s: %v", manifest.Path, err)
+		}
+
+		if podName != "" && pod.Name != podName {
+			continue
+		}
+
+		if err := podFunc(ctx, d, pod, manifest.Object, manifest.sourceInfo); err != nil {
+			return err
+		}
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func buildKubernetesPodObjectFieldSelectorFilter(ctx context.Context, d *plugin.QueryData) []string {
+	filterQuals := map[string]string{
+		"pod_name":  "metadata.name",
+		"node_name": "spec.nodeName",
+		"phase":     "status.phase",
+	}
+
+	fieldSelectors := []string{}
+	for columnName, filterName := range filterQuals {
+		if d.KeyColumnQualString(columnName) != "" {
+			fieldSelectors = append(fieldSelectors, filterName+"="+d.KeyColumnQualString(columnName))
+		}
+	}
+
+	return fieldSelectors
+}
+
+// podObjectContainers :: the unstructured containers of a pod, by field, e.g. initContainers
+func podObjectContainers(object map[string]interface{}, field string) []map[string]interface{} {
+	spec, _ := object["spec"].(map[string]interface{})
+	items, _ := spec[field].([]interface{})
+
+	containers := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		container, _ := item.(map[string]interface{})
+		containers = append(containers, container)
+	}
+	return containers
+}
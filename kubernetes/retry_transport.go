@@ -0,0 +1,163 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/context_key"
+)
+
+const (
+	defaultMaxRetries = 3
+	retryBaseDelay    = 250 * time.Millisecond
+	retryMaxDelay     = 30 * time.Second
+)
+
+// retryRoundTripper retries read requests that were throttled (429), failed on the
+// API server (5xx) or lost their connection. Retries back off exponentially with
+// jitter, unless the server asks for a specific delay with Retry-After.
+//
+// client-go retries the same failures itself, up to 10 times, so the round tripper
+// also stops it from retrying once it gives up: Retry-After is dropped from the last
+// response and a lost connection is reported as a retriesExhaustedError.
+type retryRoundTripper struct {
+	delegate   http.RoundTripper
+	maxRetries int
+}
+
+func newRetryRoundTripper(maxRetries int) func(http.RoundTripper) http.RoundTripper {
+	return func(delegate http.RoundTripper) http.RoundTripper {
+		return &retryRoundTripper{delegate: delegate, maxRetries: maxRetries}
+	}
+}
+
+func (rt *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Only list and get calls are retried; anything else is passed through
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		resp, err := rt.delegate.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		return withoutClientRetry(req, 0, resp, nil)
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := rt.delegate.RoundTrip(req)
+		if attempt >= rt.maxRetries || !shouldRetryRequest(resp, err) {
+			return withoutClientRetry(req, attempt, resp, err)
+		}
+
+		delay := retryDelay(attempt, resp)
+		if resp != nil {
+			logRetry(req, "retrying request", "delay", delay, "status", resp.Status)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			logRetry(req, "retrying request", "delay", delay, "error", err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// logRetry :: log a retry with the plugin logger carried by the request's context. plugin.Logger
+// panics on a context without one, so such requests are retried without logging.
+func logRetry(req *http.Request, msg string, args ...interface{}) {
+	if req.Context().Value(context_key.Logger) == nil {
+		return
+	}
+	args = append([]interface{}{"method", req.Method, "path", req.URL.Path}, args...)
+	plugin.Logger(req.Context()).Warn(msg, args...)
+}
+
+// retriesExhaustedError reports a connection lost on the last attempt of a request. It
+// does not wrap the cause, as client-go would retry the request again on a connection
+// reset or EOF; the cause is logged instead.
+type retriesExhaustedError struct {
+	retries int
+}
+
+func (e *retriesExhaustedError) Error() string {
+	return fmt.Sprintf("connection to the API server lost after %d retries", e.retries)
+}
+
+// withoutClientRetry :: the outcome of the last attempt of a request, changed so that
+// client-go does not retry it
+func withoutClientRetry(req *http.Request, retries int, resp *http.Response, err error) (*http.Response, error) {
+	if err != nil {
+		if !isConnectionLostError(err) {
+			return nil, err
+		}
+		logRetry(req, "giving up on request", "retries", retries, "error", err)
+		return nil, &retriesExhaustedError{retries: retries}
+	}
+
+	// client-go only retries a 429 or 5xx response which has a Retry-After header
+	if resp.Header.Get("Retry-After") != "" && shouldRetryRequest(resp, nil) {
+		resp.Header = resp.Header.Clone()
+		resp.Header.Del("Retry-After")
+	}
+	return resp, nil
+}
+
+func shouldRetryRequest(resp *http.Response, err error) bool {
+	if err != nil {
+		return isConnectionLostError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// isConnectionLostError :: whether the connection was reset or closed mid request. This
+// covers the errors client-go itself retries.
+func isConnectionLostError(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || utilnet.IsConnectionReset(err) || utilnet.IsProbableEOF(err)
+}
+
+// retryDelay :: the Retry-After delay requested by the server, or an exponential backoff with jitter
+func retryDelay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if value := resp.Header.Get("Retry-After"); value != "" {
+			if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+				return minDuration(time.Duration(seconds)*time.Second, retryMaxDelay)
+			}
+			if date, err := http.ParseTime(value); err == nil {
+				return minDuration(time.Until(date), retryMaxDelay)
+			}
+		}
+	}
+
+	backoff := minDuration(retryBaseDelay<<attempt, retryMaxDelay)
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
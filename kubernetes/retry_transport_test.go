@@ -0,0 +1,224 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		min        time.Duration
+		max        time.Duration
+	}{
+		{name: "retry after seconds", retryAfter: "5", min: 5 * time.Second, max: 5 * time.Second},
+		{name: "retry after zero", retryAfter: "0", min: 0, max: 0},
+		{name: "retry after seconds is capped", retryAfter: "120", min: retryMaxDelay, max: retryMaxDelay},
+		{name: "retry after date", retryAfter: time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), min: 8 * time.Second, max: 10 * time.Second},
+		{name: "retry after date is capped", retryAfter: time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), min: retryMaxDelay, max: retryMaxDelay},
+		{name: "invalid retry after falls back to backoff", retryAfter: "soon", min: retryBaseDelay / 2, max: retryBaseDelay},
+		{name: "negative retry after falls back to backoff", retryAfter: "-1", min: retryBaseDelay / 2, max: retryBaseDelay},
+		{name: "backoff without retry after", min: retryBaseDelay / 2, max: retryBaseDelay},
+		{name: "backoff grows with attempts", attempt: 3, min: 4 * retryBaseDelay, max: 8 * retryBaseDelay},
+		{name: "backoff is capped", attempt: 20, min: retryMaxDelay / 2, max: retryMaxDelay},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+			if test.retryAfter != "" {
+				resp.Header.Set("Retry-After", test.retryAfter)
+			}
+
+			delay := retryDelay(test.attempt, resp)
+			if delay < test.min || delay > test.max {
+				t.Errorf("retryDelay() = %s, want between %s and %s", delay, test.min, test.max)
+			}
+		})
+	}
+}
+
+func TestRetryDelayWithoutResponse(t *testing.T) {
+	delay := retryDelay(0, nil)
+	if delay < retryBaseDelay/2 || delay > retryBaseDelay {
+		t.Errorf("retryDelay() = %s, want between %s and %s", delay, retryBaseDelay/2, retryBaseDelay)
+	}
+}
+
+func TestShouldRetryRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		err    error
+		want   bool
+	}{
+		{name: "ok", status: http.StatusOK, want: false},
+		{name: "not found", status: http.StatusNotFound, want: false},
+		{name: "forbidden", status: http.StatusForbidden, want: false},
+		{name: "too many requests", status: http.StatusTooManyRequests, want: true},
+		{name: "internal server error", status: http.StatusInternalServerError, want: true},
+		{name: "not implemented", status: http.StatusNotImplemented, want: false},
+		{name: "bad gateway", status: http.StatusBadGateway, want: true},
+		{name: "service unavailable", status: http.StatusServiceUnavailable, want: true},
+		{name: "gateway timeout", status: http.StatusGatewayTimeout, want: true},
+		{name: "connection reset", err: &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, want: true},
+		{name: "wrapped connection reset", err: fmt.Errorf("list pods: %w", syscall.ECONNRESET), want: true},
+		{name: "unexpected eof", err: io.ErrUnexpectedEOF, want: true},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, want: false},
+		{name: "other error", err: errors.New("x509: certificate signed by unknown authority"), want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var resp *http.Response
+			if test.err == nil {
+				resp = &http.Response{StatusCode: test.status}
+			}
+
+			if got := shouldRetryRequest(resp, test.err); got != test.want {
+				t.Errorf("shouldRetryRequest() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+// countingRoundTripper answers every request with a 503 asking for an immediate retry
+type countingRoundTripper struct {
+	calls int
+}
+
+func (rt *countingRoundTripper) RoundTrip(_ *http.Request) (*http.Response, error) {
+	rt.calls++
+	return &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Status:     "503 Service Unavailable",
+		Header:     http.Header{"Retry-After": []string{"0"}},
+		Body:       io.NopCloser(strings.NewReader("")),
+	}, nil
+}
+
+func TestRetryRoundTripperMethods(t *testing.T) {
+	tests := []struct {
+		method string
+		calls  int
+	}{
+		{method: http.MethodGet, calls: 3},
+		{method: http.MethodHead, calls: 3},
+		{method: http.MethodPost, calls: 1},
+		{method: http.MethodPut, calls: 1},
+		{method: http.MethodPatch, calls: 1},
+		{method: http.MethodDelete, calls: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.method, func(t *testing.T) {
+			delegate := &countingRoundTripper{}
+			rt := newRetryRoundTripper(2)(delegate)
+
+			resp, err := rt.RoundTrip(httptest.NewRequest(test.method, "https://kubernetes.default/api/v1/pods", nil))
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			if resp.StatusCode != http.StatusServiceUnavailable {
+				t.Errorf("RoundTrip() status = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+			}
+			if delegate.calls != test.calls {
+				t.Errorf("RoundTrip() made %d calls, want %d", delegate.calls, test.calls)
+			}
+		})
+	}
+}
+
+func TestRetryWithRESTClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries *int
+		// status of each response, the last one repeating
+		statuses []int
+		// close the connection instead of answering
+		dropConnection bool
+		calls          int
+		wantErr        bool
+	}{
+		{name: "ok", statuses: []int{http.StatusOK}, calls: 1},
+		{name: "throttled then ok", statuses: []int{http.StatusTooManyRequests, http.StatusOK}, calls: 2},
+		{name: "throttled with default retries", statuses: []int{http.StatusTooManyRequests}, calls: defaultMaxRetries + 1, wantErr: true},
+		{name: "unavailable with two retries", maxRetries: intPointer(2), statuses: []int{http.StatusServiceUnavailable}, calls: 3, wantErr: true},
+		{name: "retries disabled", maxRetries: intPointer(0), statuses: []int{http.StatusTooManyRequests}, calls: 1, wantErr: true},
+		{name: "not found is not retried", statuses: []int{http.StatusNotFound}, calls: 1, wantErr: true},
+		{name: "dropped connection", maxRetries: intPointer(1), dropConnection: true, calls: 2, wantErr: true},
+		{name: "dropped connection with retries disabled", maxRetries: intPointer(0), dropConnection: true, calls: 1, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if test.dropConnection {
+					conn, _, err := w.(http.Hijacker).Hijack()
+					if err == nil {
+						conn.Close()
+					}
+					return
+				}
+
+				status := test.statuses[minInt(calls, len(test.statuses))-1]
+				w.Header().Set("Content-Type", "application/json")
+				if status != http.StatusOK {
+					// ask for an immediate retry, which client-go would also honor
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(status)
+					fmt.Fprintf(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","code":%d}`, status)
+					return
+				}
+				fmt.Fprint(w, `{"kind":"PodList","apiVersion":"v1","items":[]}`)
+			}))
+			defer server.Close()
+
+			restconfig := &rest.Config{Host: server.URL}
+			if err := applyRequestConfig(restconfig, kubernetesConfig{MaxRetries: test.maxRetries}); err != nil {
+				t.Fatal(err)
+			}
+			clientset, err := kubernetes.NewForConfig(restconfig)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = clientset.CoreV1().Pods("default").List(context.Background(), metav1.ListOptions{})
+			if (err != nil) != test.wantErr {
+				t.Errorf("List() error = %v, want error %v", err, test.wantErr)
+			}
+			if calls != test.calls {
+				t.Errorf("List() made %d calls, want %d", calls, test.calls)
+			}
+		})
+	}
+}
+
+func intPointer(i int) *int {
+	return &i
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
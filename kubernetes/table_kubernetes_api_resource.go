@@ -0,0 +1,161 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// APIResource is a row of the kubernetes_api_resource table
+type APIResource struct {
+	metav1.APIResource
+	APIVersion         string
+	IsPreferredVersion bool
+	IsSubresource      bool
+}
+
+func tableKubernetesAPIResource(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_api_resource",
+		Description:       "Resources served by the cluster, as reported by API discovery. There is one row per group, version and resource, including subresources.",
+		GetMatrixItemFunc: BuildContextList,
+		List: &plugin.ListConfig{
+			Hydrate: listK8sAPIResources,
+		},
+		Columns: append([]*plugin.Column{
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Plural name of the resource, e.g. deployments. Subresources are named <resource>/<subresource>, e.g. pods/log.",
+			},
+			{
+				Name:        "group",
+				Type:        proto.ColumnType_STRING,
+				Description: "API group of the resource. Empty for the core group.",
+			},
+			{
+				Name:        "version",
+				Type:        proto.ColumnType_STRING,
+				Description: "API version of the resource within its group, e.g. v1.",
+			},
+			{
+				Name:        "api_version",
+				Type:        proto.ColumnType_STRING,
+				Description: "Group and version of the resource, as used in the apiVersion of objects, e.g. apps/v1.",
+				Transform:   transform.FromField("APIVersion"),
+			},
+			{
+				Name:        "kind",
+				Type:        proto.ColumnType_STRING,
+				Description: "Kind of the objects of the resource, e.g. Deployment.",
+			},
+			{
+				Name:        "singular_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Singular name of the resource.",
+			},
+			{
+				Name:        "namespaced",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the objects of the resource are namespaced.",
+			},
+			{
+				Name:        "verbs",
+				Type:        proto.ColumnType_JSON,
+				Description: "Verbs supported by the resource, e.g. get, list and watch.",
+			},
+			{
+				Name:        "short_names",
+				Type:        proto.ColumnType_JSON,
+				Description: "Short names of the resource, e.g. deploy.",
+			},
+			{
+				Name:        "categories",
+				Type:        proto.ColumnType_JSON,
+				Description: "Categories the resource belongs to, e.g. all.",
+			},
+			{
+				Name:        "storage_version_hash",
+				Type:        proto.ColumnType_STRING,
+				Description: "Hash of the version objects of the resource are stored in. Changes when the storage version changes.",
+			},
+			{
+				Name:        "is_preferred_version",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the version is the preferred version of its group.",
+			},
+			{
+				Name:        "is_subresource",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the resource is a subresource, such as pods/log or deployments/scale.",
+			},
+		}, kubectlConfigColumns()...),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sAPIResources(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sAPIResources")
+
+	// manifest sources have no API server to discover
+	if isManifestSource(d) {
+		return nil, nil
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, resourceLists, err := clientset.Discovery().ServerGroupsAndResources()
+	if err != nil {
+		// an unavailable aggregated API fails its own group only
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, err
+		}
+		logger.Warn("listK8sAPIResources", "discovery_error", err)
+	}
+
+	preferredVersions := map[string]string{}
+	for _, group := range groups {
+		preferredVersions[group.Name] = group.PreferredVersion.Version
+	}
+
+	for _, resourceList := range resourceLists {
+		groupVersion, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range resourceList.APIResources {
+			// discovery only sets the group and version of resources served from another group version
+			if resource.Group == "" && resource.Version == "" {
+				resource.Group = groupVersion.Group
+				resource.Version = groupVersion.Version
+			}
+
+			d.StreamListItem(ctx, APIResource{
+				APIResource:        resource,
+				APIVersion:         resourceList.GroupVersion,
+				IsPreferredVersion: preferredVersions[groupVersion.Group] == groupVersion.Version,
+				IsSubresource:      strings.Contains(resource.Name, "/"),
+			})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
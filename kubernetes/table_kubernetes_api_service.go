@@ -0,0 +1,333 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// apiServiceResource is read with the dynamic client, as the typed client is in
+// k8s.io/kube-aggregator which is not a dependency of the plugin
+var apiServiceResource = schema.GroupVersionResource{Group: "apiregistration.k8s.io", Version: "v1", Resource: "apiservices"}
+
+// apiService mirrors the fields of an apiregistration.k8s.io/v1 APIService the table reads
+type apiService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              apiServiceSpec   `json:"spec,omitempty"`
+	Status            apiServiceStatus `json:"status,omitempty"`
+}
+
+type apiServiceSpec struct {
+	Service               *apiServiceReference `json:"service,omitempty"`
+	Group                 string               `json:"group,omitempty"`
+	Version               string               `json:"version,omitempty"`
+	InsecureSkipTLSVerify bool                 `json:"insecureSkipTLSVerify,omitempty"`
+	CABundle              []byte               `json:"caBundle,omitempty"`
+	GroupPriorityMinimum  int32                `json:"groupPriorityMinimum"`
+	VersionPriority       int32                `json:"versionPriority"`
+}
+
+type apiServiceReference struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Port      *int32 `json:"port,omitempty"`
+}
+
+type apiServiceStatus struct {
+	Conditions []apiServiceCondition `json:"conditions,omitempty"`
+}
+
+type apiServiceCondition struct {
+	Type               string      `json:"type"`
+	Status             string      `json:"status"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	Reason             string      `json:"reason,omitempty"`
+	Message            string      `json:"message,omitempty"`
+}
+
+// APIService is a row of the kubernetes_api_service table
+type APIService struct {
+	apiService
+	sourceInfo
+}
+
+func tableKubernetesAPIService(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_api_service",
+		Description:       "An APIService registers a version of an API group with the API server, either served by the API server itself or by an aggregated API server behind a service, such as metrics-server. Unavailable aggregated APIs cause discovery to fail.",
+		GetMatrixItemFunc: BuildContextList,
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
+			Hydrate:    getK8sAPIService,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listK8sAPIServices,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
+		},
+		Columns: k8sCommonGlobalColumns([]*plugin.Column{
+			{
+				Name:        "group",
+				Type:        proto.ColumnType_STRING,
+				Description: "API group the APIService registers. Empty for the core group.",
+				Transform:   transform.FromField("Spec.Group"),
+			},
+			{
+				Name:        "version",
+				Type:        proto.ColumnType_STRING,
+				Description: "Version of the API group the APIService registers, e.g. v1beta1.",
+				Transform:   transform.FromField("Spec.Version"),
+			},
+			{
+				Name:        "local",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the API is served by the API server itself rather than by an aggregated API server behind a service.",
+				Transform:   transform.From(transformAPIServiceLocal),
+			},
+			{
+				Name:        "service_namespace",
+				Type:        proto.ColumnType_STRING,
+				Description: "Namespace of the service of the aggregated API server.",
+				Transform:   transform.FromField("Spec.Service.Namespace"),
+			},
+			{
+				Name:        "service_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the service of the aggregated API server.",
+				Transform:   transform.FromField("Spec.Service.Name"),
+			},
+			{
+				Name:        "service_port",
+				Type:        proto.ColumnType_INT,
+				Description: "Port of the service of the aggregated API server. Defaults to 443.",
+				Transform:   transform.FromField("Spec.Service.Port"),
+			},
+			{
+				Name:        "insecure_skip_tls_verify",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the API server does not verify the TLS certificate of the aggregated API server.",
+				Transform:   transform.FromField("Spec.InsecureSkipTLSVerify"),
+			},
+			{
+				Name:        "has_ca_bundle",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if a CA bundle is set to verify the TLS certificate of the aggregated API server.",
+				Transform:   transform.From(transformAPIServiceHasCABundle),
+			},
+			{
+				Name:        "group_priority_minimum",
+				Type:        proto.ColumnType_INT,
+				Description: "Priority of the API group, used to order groups in discovery. Higher values come first.",
+				Transform:   transform.FromField("Spec.GroupPriorityMinimum"),
+			},
+			{
+				Name:        "version_priority",
+				Type:        proto.ColumnType_INT,
+				Description: "Priority of the version within its group, used to order versions in discovery. Higher values come first.",
+				Transform:   transform.FromField("Spec.VersionPriority"),
+			},
+			{
+				Name:        "available_status",
+				Type:        proto.ColumnType_STRING,
+				Description: "Status of the Available condition: True, False or Unknown.",
+				Transform:   transform.FromP(transformAPIServiceAvailableCondition, "Status"),
+			},
+			{
+				Name:        "available_reason",
+				Type:        proto.ColumnType_STRING,
+				Description: "Reason for the status of the Available condition, e.g. Local, Passed, MissingEndpoints or FailedDiscoveryCheck.",
+				Transform:   transform.FromP(transformAPIServiceAvailableCondition, "Reason"),
+			},
+			{
+				Name:        "available_message",
+				Type:        proto.ColumnType_STRING,
+				Description: "Message about the status of the Available condition.",
+				Transform:   transform.FromP(transformAPIServiceAvailableCondition, "Message"),
+			},
+			{
+				Name:        "available_last_transition_time",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time the Available condition last changed status.",
+				Transform:   transform.FromP(transformAPIServiceAvailableCondition, "LastTransitionTime").Transform(v1TimeToRFC3339),
+			},
+			{
+				Name:        "conditions",
+				Type:        proto.ColumnType_JSON,
+				Description: "Conditions of the APIService.",
+				Transform:   transform.FromField("Status.Conditions"),
+			},
+
+			//// Steampipe Standard Columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: ColumnDescriptionTitle,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Type:        proto.ColumnType_JSON,
+				Description: ColumnDescriptionTags,
+				Transform:   transform.From(transformAPIServiceTags),
+			},
+		}),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sAPIServices(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sAPIServices")
+
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newAPIServiceRow, schema.GroupKind{Group: "apiregistration.k8s.io", Kind: "APIService"})
+	}
+
+	client, err := GetNewClientDynamic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	// Limiting the results
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < input.Limit {
+			if *limit < 1 {
+				input.Limit = 1
+			} else {
+				input.Limit = *limit
+			}
+		}
+	}
+
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
+	pageLeft := true
+
+	for pageLeft {
+		response, err := client.Resource(apiServiceResource).List(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.GetContinue()
+		} else {
+			pageLeft = false
+		}
+
+		for _, item := range response.Items {
+			row := APIService{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &row.apiService); err != nil {
+				return nil, err
+			}
+			d.StreamListItem(ctx, row)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getK8sAPIService(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("getK8sAPIService")
+
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newAPIServiceRow, schema.GroupKind{Group: "apiregistration.k8s.io", Kind: "APIService"})
+	}
+
+	client, err := GetNewClientDynamic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	name := d.KeyColumnQuals["name"].GetStringValue()
+
+	// return if name is empty
+	if name == "" {
+		return nil, nil
+	}
+
+	item, err := client.Resource(apiServiceResource).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	row := APIService{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &row.apiService); err != nil {
+		return nil, err
+	}
+
+	return row, nil
+}
+
+func newAPIServiceRow(manifest manifestObject) (interface{}, error) {
+	var obj apiService
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return APIService{apiService: obj, sourceInfo: manifest.sourceInfo}, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func transformAPIServiceLocal(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	return d.HydrateItem.(APIService).Spec.Service == nil, nil
+}
+
+func transformAPIServiceHasCABundle(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	return len(d.HydrateItem.(APIService).Spec.CABundle) > 0, nil
+}
+
+// transformAPIServiceAvailableCondition :: the field of the Available condition given as the transform param
+func transformAPIServiceAvailableCondition(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	for _, condition := range d.HydrateItem.(APIService).Status.Conditions {
+		if condition.Type != "Available" {
+			continue
+		}
+
+		switch d.Param.(string) {
+		case "Status":
+			return condition.Status, nil
+		case "Reason":
+			return condition.Reason, nil
+		case "Message":
+			return condition.Message, nil
+		case "LastTransitionTime":
+			return condition.LastTransitionTime, nil
+		}
+	}
+	return nil, nil
+}
+
+func transformAPIServiceTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(APIService)
+	return mergeTags(obj.Labels, obj.Annotations), nil
+}
@@ -0,0 +1,392 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// certificateSigningRequestDetails :: details decoded from the PEM encoded request and issued certificate
+type certificateSigningRequestDetails struct {
+	CommonName           string
+	Organizations        []string
+	DNSNames             []string
+	IPAddresses          []string
+	EmailAddresses       []string
+	URIs                 []string
+	KeyAlgorithm         string
+	CertificateNotBefore *time.Time
+	CertificateNotAfter  *time.Time
+}
+
+// CertificateSigningRequest is a row of the kubernetes_certificate_signing_request table
+type CertificateSigningRequest struct {
+	certificatesv1.CertificateSigningRequest
+	sourceInfo
+}
+
+func tableKubernetesCertificateSigningRequest(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_certificate_signing_request",
+		Description:       "A CertificateSigningRequest asks a signer to issue an X.509 certificate, e.g. a client certificate to authenticate to the API server. The request is decoded to show the subject and names asked for.",
+		GetMatrixItemFunc: BuildContextList,
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
+			Hydrate:    getK8sCertificateSigningRequest,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listK8sCertificateSigningRequests,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
+		},
+		Columns: k8sCommonGlobalColumns([]*plugin.Column{
+			{
+				Name:        "signer_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the signer asked to sign the certificate, e.g. kubernetes.io/kube-apiserver-client.",
+				Transform:   transform.FromField("Spec.SignerName"),
+			},
+			{
+				Name:        "usages",
+				Type:        proto.ColumnType_JSON,
+				Description: "Key usages requested for the certificate, e.g. client auth.",
+				Transform:   transform.FromField("Spec.Usages"),
+			},
+			{
+				Name:        "expiration_seconds",
+				Type:        proto.ColumnType_INT,
+				Description: "Validity requested for the certificate, in seconds. The signer may issue a certificate with a different validity.",
+				Transform:   transform.FromField("Spec.ExpirationSeconds"),
+			},
+			{
+				Name:        "username",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the user who created the request, set by the API server.",
+				Transform:   transform.FromField("Spec.Username"),
+			},
+			{
+				Name:        "requester_uid",
+				Type:        proto.ColumnType_STRING,
+				Description: "UID of the user who created the request, set by the API server.",
+				Transform:   transform.FromField("Spec.UID"),
+			},
+			{
+				Name:        "groups",
+				Type:        proto.ColumnType_JSON,
+				Description: "Groups of the user who created the request, set by the API server.",
+				Transform:   transform.FromField("Spec.Groups"),
+			},
+			{
+				Name:        "extra",
+				Type:        proto.ColumnType_JSON,
+				Description: "Extra attributes of the user who created the request, set by the API server.",
+				Transform:   transform.FromField("Spec.Extra"),
+			},
+			{
+				Name:        "request_common_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Common name (CN) of the subject of the requested certificate. For client certificates, this is the user name the certificate authenticates as.",
+				Hydrate:     decodeK8sCertificateSigningRequest,
+				Transform:   transform.FromField("CommonName"),
+			},
+			{
+				Name:        "request_organizations",
+				Type:        proto.ColumnType_JSON,
+				Description: "Organizations (O) of the subject of the requested certificate. For client certificates, these are the groups the certificate authenticates as.",
+				Hydrate:     decodeK8sCertificateSigningRequest,
+				Transform:   transform.FromField("Organizations"),
+			},
+			{
+				Name:        "request_dns_names",
+				Type:        proto.ColumnType_JSON,
+				Description: "DNS subject alternative names of the requested certificate.",
+				Hydrate:     decodeK8sCertificateSigningRequest,
+				Transform:   transform.FromField("DNSNames"),
+			},
+			{
+				Name:        "request_ip_addresses",
+				Type:        proto.ColumnType_JSON,
+				Description: "IP address subject alternative names of the requested certificate.",
+				Hydrate:     decodeK8sCertificateSigningRequest,
+				Transform:   transform.FromField("IPAddresses"),
+			},
+			{
+				Name:        "request_email_addresses",
+				Type:        proto.ColumnType_JSON,
+				Description: "Email subject alternative names of the requested certificate.",
+				Hydrate:     decodeK8sCertificateSigningRequest,
+				Transform:   transform.FromField("EmailAddresses"),
+			},
+			{
+				Name:        "request_uris",
+				Type:        proto.ColumnType_JSON,
+				Description: "URI subject alternative names of the requested certificate.",
+				Hydrate:     decodeK8sCertificateSigningRequest,
+				Transform:   transform.FromField("URIs"),
+			},
+			{
+				Name:        "request_key_algorithm",
+				Type:        proto.ColumnType_STRING,
+				Description: "Algorithm of the public key in the request: RSA, ECDSA or Ed25519.",
+				Hydrate:     decodeK8sCertificateSigningRequest,
+				Transform:   transform.FromField("KeyAlgorithm").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "approved",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the request has an Approved condition.",
+				Transform:   transform.FromP(transformCertificateSigningRequestHasCondition, certificatesv1.CertificateApproved),
+			},
+			{
+				Name:        "denied",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the request has a Denied condition.",
+				Transform:   transform.FromP(transformCertificateSigningRequestHasCondition, certificatesv1.CertificateDenied),
+			},
+			{
+				Name:        "failed",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the request has a Failed condition, i.e. the signer failed to issue the certificate.",
+				Transform:   transform.FromP(transformCertificateSigningRequestHasCondition, certificatesv1.CertificateFailed),
+			},
+			{
+				Name:        "conditions",
+				Type:        proto.ColumnType_JSON,
+				Description: "Approval and failure conditions of the request, with the reason, message and time of each.",
+				Transform:   transform.FromField("Status.Conditions"),
+			},
+			{
+				Name:        "certificate_issued",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the signer has issued the certificate.",
+				Transform:   transform.FromField("Status.Certificate").Transform(transformCertificateSigningRequestIssued),
+			},
+			{
+				Name:        "certificate_not_before",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time the issued certificate is valid from.",
+				Hydrate:     decodeK8sCertificateSigningRequest,
+				Transform:   transform.FromField("CertificateNotBefore"),
+			},
+			{
+				Name:        "certificate_not_after",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time the issued certificate expires.",
+				Hydrate:     decodeK8sCertificateSigningRequest,
+				Transform:   transform.FromField("CertificateNotAfter"),
+			},
+
+			//// Steampipe Standard Columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: ColumnDescriptionTitle,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Type:        proto.ColumnType_JSON,
+				Description: ColumnDescriptionTags,
+				Transform:   transform.From(transformCertificateSigningRequestTags),
+			},
+		}),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sCertificateSigningRequests(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sCertificateSigningRequests")
+
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newCertificateSigningRequestRow, schema.GroupKind{Group: "certificates.k8s.io", Kind: "CertificateSigningRequest"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	// Limiting the results
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < input.Limit {
+			if *limit < 1 {
+				input.Limit = 1
+			} else {
+				input.Limit = *limit
+			}
+		}
+	}
+
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
+	var response *certificatesv1.CertificateSigningRequestList
+	pageLeft := true
+
+	for pageLeft {
+		response, err = clientset.CertificatesV1().CertificateSigningRequests().List(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+		} else {
+			pageLeft = false
+		}
+
+		for _, csr := range response.Items {
+			d.StreamListItem(ctx, CertificateSigningRequest{CertificateSigningRequest: csr})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getK8sCertificateSigningRequest(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("getK8sCertificateSigningRequest")
+
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newCertificateSigningRequestRow, schema.GroupKind{Group: "certificates.k8s.io", Kind: "CertificateSigningRequest"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	name := d.KeyColumnQuals["name"].GetStringValue()
+
+	// return if name is empty
+	if name == "" {
+		return nil, nil
+	}
+
+	csr, err := clientset.CertificatesV1().CertificateSigningRequests().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return CertificateSigningRequest{CertificateSigningRequest: *csr}, nil
+}
+
+// decodeK8sCertificateSigningRequest :: decode the request, and the certificate if it has been
+// issued. Fields which cannot be decoded are left empty rather than failing the query.
+func decodeK8sCertificateSigningRequest(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("decodeK8sCertificateSigningRequest")
+
+	csr := h.Item.(CertificateSigningRequest)
+
+	details, requestErr, certificateErr := decodeCertificateSigningRequest(csr.CertificateSigningRequest)
+	if requestErr != nil {
+		logger.Warn("decodeK8sCertificateSigningRequest", "name", csr.Name, "request_error", requestErr)
+	}
+	if certificateErr != nil {
+		logger.Warn("decodeK8sCertificateSigningRequest", "name", csr.Name, "certificate_error", certificateErr)
+	}
+
+	return details, nil
+}
+
+// decodeCertificateSigningRequest :: details of the PEM encoded request and issued certificate.
+// The details of a part which cannot be parsed are left empty and its error returned.
+func decodeCertificateSigningRequest(csr certificatesv1.CertificateSigningRequest) (details certificateSigningRequestDetails, requestErr error, certificateErr error) {
+	if block, _ := pem.Decode(csr.Spec.Request); block != nil {
+		request, err := x509.ParseCertificateRequest(block.Bytes)
+		if err != nil {
+			requestErr = err
+		} else {
+			details.CommonName = request.Subject.CommonName
+			details.Organizations = request.Subject.Organization
+			details.DNSNames = request.DNSNames
+			details.EmailAddresses = request.EmailAddresses
+			for _, ip := range request.IPAddresses {
+				details.IPAddresses = append(details.IPAddresses, ip.String())
+			}
+			for _, uri := range request.URIs {
+				details.URIs = append(details.URIs, uri.String())
+			}
+			if request.PublicKeyAlgorithm != x509.UnknownPublicKeyAlgorithm {
+				details.KeyAlgorithm = request.PublicKeyAlgorithm.String()
+			}
+		}
+	}
+
+	// the issued certificate is the first in the chain
+	if block, _ := pem.Decode(csr.Status.Certificate); block != nil {
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			certificateErr = err
+		} else {
+			details.CertificateNotBefore = &certificate.NotBefore
+			details.CertificateNotAfter = &certificate.NotAfter
+		}
+	}
+
+	return details, requestErr, certificateErr
+}
+
+func newCertificateSigningRequestRow(manifest manifestObject) (interface{}, error) {
+	var obj certificatesv1.CertificateSigningRequest
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return CertificateSigningRequest{CertificateSigningRequest: obj, sourceInfo: manifest.sourceInfo}, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func transformCertificateSigningRequestHasCondition(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	conditionType := d.Param.(certificatesv1.RequestConditionType)
+
+	for _, condition := range d.HydrateItem.(CertificateSigningRequest).Status.Conditions {
+		// an empty status is defaulted to True
+		if condition.Type == conditionType && condition.Status != v1.ConditionFalse && condition.Status != v1.ConditionUnknown {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func transformCertificateSigningRequestIssued(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	certificate, ok := d.Value.([]byte)
+	return ok && len(certificate) > 0, nil
+}
+
+func transformCertificateSigningRequestTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(CertificateSigningRequest)
+	return mergeTags(obj.Labels, obj.Annotations), nil
+}
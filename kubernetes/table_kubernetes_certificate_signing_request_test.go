@@ -0,0 +1,173 @@
+package kubernetes
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+)
+
+// newCertificateSigningRequestFixtures :: a PEM encoded request for a kubelet serving
+// certificate, and a certificate issued for it valid from notBefore to notAfter
+func newCertificateSigningRequestFixtures(t *testing.T, notBefore time.Time, notAfter time.Time) (request []byte, certificate []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subject := pkix.Name{CommonName: "system:node:worker-1", Organization: []string{"system:nodes"}}
+	spiffe, _ := url.Parse("spiffe://cluster.local/node/worker-1")
+
+	requestDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:        subject,
+		DNSNames:       []string{"worker-1", "worker-1.example.internal"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.21"), net.ParseIP("fd00::21")},
+		EmailAddresses: []string{"ops@example.com"},
+		URIs:           []*url.URL{spiffe},
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificateDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      subject,
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		DNSNames:     []string{"worker-1"},
+	}, &x509.Certificate{SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "kubernetes"}}, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: requestDER})
+	certificate = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateDER})
+	return request, certificate
+}
+
+func TestDecodeCertificateSigningRequest(t *testing.T) {
+	notBefore := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	request, certificate := newCertificateSigningRequestFixtures(t, notBefore, notAfter)
+
+	requestDetails := certificateSigningRequestDetails{
+		CommonName:     "system:node:worker-1",
+		Organizations:  []string{"system:nodes"},
+		DNSNames:       []string{"worker-1", "worker-1.example.internal"},
+		IPAddresses:    []string{"10.0.0.21", "fd00::21"},
+		EmailAddresses: []string{"ops@example.com"},
+		URIs:           []string{"spiffe://cluster.local/node/worker-1"},
+		KeyAlgorithm:   "ECDSA",
+	}
+	issuedDetails := requestDetails
+	issuedDetails.CertificateNotBefore = &notBefore
+	issuedDetails.CertificateNotAfter = &notAfter
+
+	garbage := []byte("-----BEGIN CERTIFICATE REQUEST-----\nbm90IGEgY2VydGlmaWNhdGUgcmVxdWVzdA==\n-----END CERTIFICATE REQUEST-----\n")
+	garbageCertificate := []byte("-----BEGIN CERTIFICATE-----\nbm90IGEgY2VydGlmaWNhdGU=\n-----END CERTIFICATE-----\n")
+
+	// the issued certificate followed by its CA
+	chain := append(append([]byte{}, certificate...), certificate...)
+
+	tests := []struct {
+		name               string
+		request            []byte
+		certificate        []byte
+		want               certificateSigningRequestDetails
+		wantRequestErr     bool
+		wantCertificateErr bool
+	}{
+		{
+			name:    "pending request",
+			request: request,
+			want:    requestDetails,
+		},
+		{
+			name:        "issued certificate",
+			request:     request,
+			certificate: certificate,
+			want:        issuedDetails,
+		},
+		{
+			name:        "issued certificate chain",
+			request:     request,
+			certificate: chain,
+			want:        issuedDetails,
+		},
+		{
+			name: "no request",
+			want: certificateSigningRequestDetails{},
+		},
+		{
+			name:    "request which is not PEM",
+			request: []byte("not a certificate request"),
+			want:    certificateSigningRequestDetails{},
+		},
+		{
+			name:           "garbage request",
+			request:        garbage,
+			want:           certificateSigningRequestDetails{},
+			wantRequestErr: true,
+		},
+		{
+			name:           "garbage request with an issued certificate",
+			request:        garbage,
+			certificate:    certificate,
+			want:           certificateSigningRequestDetails{CertificateNotBefore: &notBefore, CertificateNotAfter: &notAfter},
+			wantRequestErr: true,
+		},
+		{
+			name:               "garbage certificate",
+			request:            request,
+			certificate:        garbageCertificate,
+			want:               requestDetails,
+			wantCertificateErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			csr := certificatesv1.CertificateSigningRequest{
+				Spec:   certificatesv1.CertificateSigningRequestSpec{Request: test.request},
+				Status: certificatesv1.CertificateSigningRequestStatus{Certificate: test.certificate},
+			}
+
+			got, requestErr, certificateErr := decodeCertificateSigningRequest(csr)
+			if (requestErr != nil) != test.wantRequestErr {
+				t.Errorf("decodeCertificateSigningRequest() request error = %v, want error %v", requestErr, test.wantRequestErr)
+			}
+			if (certificateErr != nil) != test.wantCertificateErr {
+				t.Errorf("decodeCertificateSigningRequest() certificate error = %v, want error %v", certificateErr, test.wantCertificateErr)
+			}
+
+			if !equalTimes(got.CertificateNotBefore, test.want.CertificateNotBefore) || !equalTimes(got.CertificateNotAfter, test.want.CertificateNotAfter) {
+				t.Errorf("decodeCertificateSigningRequest() validity = %v - %v, want %v - %v", got.CertificateNotBefore, got.CertificateNotAfter, test.want.CertificateNotBefore, test.want.CertificateNotAfter)
+			}
+			got.CertificateNotBefore, got.CertificateNotAfter = nil, nil
+			test.want.CertificateNotBefore, test.want.CertificateNotAfter = nil, nil
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("decodeCertificateSigningRequest() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func equalTimes(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
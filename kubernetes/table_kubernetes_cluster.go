@@ -0,0 +1,240 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// Cluster is a row of the kubernetes_cluster table
+type Cluster struct {
+	version.Info
+	Server string
+}
+
+func tableKubernetesCluster(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_cluster",
+		Description:       "The cluster of each context queried by the connection, with its server version and identity.",
+		GetMatrixItemFunc: BuildContextList,
+		List: &plugin.ListConfig{
+			Hydrate: listK8sClusters,
+		},
+		Columns: append([]*plugin.Column{
+			{
+				Name:        "server",
+				Type:        proto.ColumnType_STRING,
+				Description: "URL of the API server.",
+			},
+			{
+				Name:        "cluster_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "UID of the kube-system namespace, which is stable for the lifetime of the cluster.",
+				Hydrate:     getK8sClusterID,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "git_version",
+				Type:        proto.ColumnType_STRING,
+				Description: "Version of the API server, e.g. v1.25.4.",
+			},
+			{
+				Name:        "major",
+				Type:        proto.ColumnType_STRING,
+				Description: "Major version of the API server.",
+			},
+			{
+				Name:        "minor",
+				Type:        proto.ColumnType_STRING,
+				Description: "Minor version of the API server. Some distributions append a suffix, e.g. 25+.",
+			},
+			{
+				Name:        "git_commit",
+				Type:        proto.ColumnType_STRING,
+				Description: "Commit the API server was built from.",
+			},
+			{
+				Name:        "git_tree_state",
+				Type:        proto.ColumnType_STRING,
+				Description: "State of the git tree the API server was built from, either clean or dirty.",
+			},
+			{
+				Name:        "build_date",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time the API server was built.",
+			},
+			{
+				Name:        "go_version",
+				Type:        proto.ColumnType_STRING,
+				Description: "Go version the API server was built with.",
+			},
+			{
+				Name:        "compiler",
+				Type:        proto.ColumnType_STRING,
+				Description: "Compiler the API server was built with.",
+			},
+			{
+				Name:        "platform",
+				Type:        proto.ColumnType_STRING,
+				Description: "Operating system and architecture of the API server, e.g. linux/amd64.",
+			},
+			{
+				Name:        "node_count",
+				Type:        proto.ColumnType_INT,
+				Description: "Number of nodes in the cluster.",
+				Hydrate:     getK8sClusterNodeCount,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "service_account_issuer",
+				Type:        proto.ColumnType_STRING,
+				Description: "Issuer of service account tokens, from the OpenID configuration of the API server.",
+				Hydrate:     getK8sClusterServiceAccountIssuer,
+				Transform:   transform.FromValue(),
+			},
+		}, kubectlConfigColumns()...),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sClusters(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sClusters")
+
+	// manifest sources have no cluster
+	if isManifestSource(d) {
+		return nil, nil
+	}
+
+	contextName := getContextName(ctx, d)
+
+	restconfig, err := getK8RestConfig(ctx, d.Connection, contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := getNewClientsetForContext(ctx, d.Connection, contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverVersion, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	d.StreamListItem(ctx, Cluster{Info: *serverVersion, Server: restconfig.Host})
+
+	return nil, nil
+}
+
+// getK8sClusterID :: UID of the kube-system namespace, or null if the caller cannot read it
+func getK8sClusterID(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getK8sClusterID")
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := clientset.CoreV1().Namespaces().Get(ctx, "kube-system", metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsForbidden(err) || isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return string(namespace.UID), nil
+}
+
+// getK8sClusterNodeCount :: number of nodes, or null if the caller cannot list them
+func getK8sClusterNodeCount(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getK8sClusterNodeCount")
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := countNodes(ctx, clientset)
+	if err != nil {
+		if apierrors.IsForbidden(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return count, nil
+}
+
+// countNodes :: a list of a single node carries the number of remaining nodes, so the
+// nodes are only paged through if the server does not return it
+func countNodes(ctx context.Context, clientset kubernetes.Interface) (int, error) {
+	input := metav1.ListOptions{
+		Limit: 1,
+	}
+
+	count := 0
+	pageLeft := true
+	for pageLeft {
+		response, err := clientset.CoreV1().Nodes().List(ctx, input)
+		if err != nil {
+			return 0, err
+		}
+
+		count += len(response.Items)
+
+		if response.RemainingItemCount != nil {
+			return count + int(*response.RemainingItemCount), nil
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+			input.Limit = 500
+		} else {
+			pageLeft = false
+		}
+	}
+
+	return count, nil
+}
+
+// getK8sClusterServiceAccountIssuer :: issuer from /.well-known/openid-configuration, or
+// null if service account issuer discovery is unavailable to the caller
+func getK8sClusterServiceAccountIssuer(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("getK8sClusterServiceAccountIssuer")
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := clientset.Discovery().RESTClient().Get().AbsPath("/.well-known/openid-configuration").DoRaw(ctx)
+	if err != nil {
+		if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) || apierrors.IsNotFound(err) {
+			logger.Debug("getK8sClusterServiceAccountIssuer", "openid_configuration_error", err)
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var openIDConfiguration struct {
+		Issuer string `json:"issuer"`
+	}
+	if err := json.Unmarshal(data, &openIDConfiguration); err != nil {
+		return nil, err
+	}
+
+	return openIDConfiguration.Issuer, nil
+}
@@ -2,25 +2,37 @@ package kubernetes
 
 import (
 	"context"
+	"strings"
 
 	v1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// ClusterRole is a row of the kubernetes_cluster_role table
+type ClusterRole struct {
+	v1.ClusterRole
+	sourceInfo
+}
+
 func tableKubernetesClusterRole(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_cluster_role",
-		Description: "ClusterRole contains rules that represent a set of permissions.",
+		Name:              "kubernetes_cluster_role",
+		Description:       "ClusterRole contains rules that represent a set of permissions.",
+		GetMatrixItemFunc: BuildContextList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.SingleColumn("name"),
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
 			Hydrate:    getK8sClusterRole,
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listK8sClusterRoles,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
 		},
 		// ClusterRole, is a non-namespaced resource.
 		Columns: k8sCommonGlobalColumns([]*plugin.Column{
@@ -58,6 +70,10 @@ func listK8sClusterRoles(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sClusterRoles")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newClusterRoleRow, schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"})
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -78,6 +94,12 @@ func listK8sClusterRoles(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 			}
 		}
 	}
+
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
 	var response *v1.ClusterRoleList
 	pageLeft := true
 	for pageLeft {
@@ -94,7 +116,7 @@ func listK8sClusterRoles(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 		}
 
 		for _, clusterRole := range response.Items {
-			d.StreamListItem(ctx, clusterRole)
+			d.StreamListItem(ctx, ClusterRole{ClusterRole: clusterRole})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -110,6 +132,10 @@ func getK8sClusterRole(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sClusterRole")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newClusterRoleRow, schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"})
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -127,12 +153,21 @@ func getK8sClusterRole(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 		return nil, err
 	}
 
-	return *clusterRole, nil
+	return ClusterRole{ClusterRole: *clusterRole}, nil
+}
+
+func newClusterRoleRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.ClusterRole
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return ClusterRole{ClusterRole: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformClusterRoleTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.ClusterRole)
+	obj := d.HydrateItem.(ClusterRole)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
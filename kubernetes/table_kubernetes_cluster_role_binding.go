@@ -2,25 +2,37 @@ package kubernetes
 
 import (
 	"context"
+	"strings"
 
 	v1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// ClusterRoleBinding is a row of the kubernetes_cluster_role_binding table
+type ClusterRoleBinding struct {
+	v1.ClusterRoleBinding
+	sourceInfo
+}
+
 func tableKubernetesClusterRoleBinding(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_cluster_role_binding",
-		Description: "A ClusterRoleBinding grants the permissions defined in a cluster role to a user or set of users. Access granted by ClusterRoleBinding is cluster-wide.",
+		Name:              "kubernetes_cluster_role_binding",
+		Description:       "A ClusterRoleBinding grants the permissions defined in a cluster role to a user or set of users. Access granted by ClusterRoleBinding is cluster-wide.",
+		GetMatrixItemFunc: BuildContextList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.SingleColumn("name"),
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
 			Hydrate:    getK8sClusterRoleBinding,
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listK8sClusterRoleBindings,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
 		},
 		Columns: k8sCommonGlobalColumns([]*plugin.Column{
 			{
@@ -72,6 +84,10 @@ func listK8sClusterRoleBindings(ctx context.Context, d *plugin.QueryData, _ *plu
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sClusterRoleBindings")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newClusterRoleBindingRow, schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"})
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -93,6 +109,12 @@ func listK8sClusterRoleBindings(ctx context.Context, d *plugin.QueryData, _ *plu
 		}
 	}
 
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
 	var response *v1.ClusterRoleBindingList
 	pageLeft := true
 
@@ -109,7 +131,7 @@ func listK8sClusterRoleBindings(ctx context.Context, d *plugin.QueryData, _ *plu
 		}
 
 		for _, clusterRoleBinding := range response.Items {
-			d.StreamListItem(ctx, clusterRoleBinding)
+			d.StreamListItem(ctx, ClusterRoleBinding{ClusterRoleBinding: clusterRoleBinding})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -125,6 +147,10 @@ func getK8sClusterRoleBinding(ctx context.Context, d *plugin.QueryData, _ *plugi
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sClusterRoleBinding")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newClusterRoleBindingRow, schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"})
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -142,12 +168,21 @@ func getK8sClusterRoleBinding(ctx context.Context, d *plugin.QueryData, _ *plugi
 		return nil, err
 	}
 
-	return *clusterRoleBinding, nil
+	return ClusterRoleBinding{ClusterRoleBinding: *clusterRoleBinding}, nil
+}
+
+func newClusterRoleBindingRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.ClusterRoleBinding
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return ClusterRoleBinding{ClusterRoleBinding: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformClusterRoleBindingTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.ClusterRoleBinding)
+	obj := d.HydrateItem.(ClusterRoleBinding)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
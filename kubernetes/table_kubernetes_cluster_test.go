@@ -0,0 +1,70 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCountNodes(t *testing.T) {
+	remaining := int64(41)
+	node := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+
+	tests := []struct {
+		name string
+		// responses to successive list calls
+		responses []*v1.NodeList
+		want      int
+	}{
+		{
+			name: "no nodes",
+			responses: []*v1.NodeList{
+				{},
+			},
+			want: 0,
+		},
+		{
+			name: "remaining item count",
+			responses: []*v1.NodeList{
+				{ListMeta: metav1.ListMeta{Continue: "1", RemainingItemCount: &remaining}, Items: []v1.Node{node}},
+			},
+			want: 42,
+		},
+		{
+			name: "paged without remaining item count",
+			responses: []*v1.NodeList{
+				{ListMeta: metav1.ListMeta{Continue: "1"}, Items: []v1.Node{node}},
+				{Items: []v1.Node{node, node}},
+			},
+			want: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			clientset := fake.NewSimpleClientset()
+			clientset.PrependReactor("list", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+				response := test.responses[calls]
+				calls++
+				return true, response, nil
+			})
+
+			got, err := countNodes(context.Background(), clientset)
+			if err != nil {
+				t.Fatalf("countNodes() error = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("countNodes() = %d, want %d", got, test.want)
+			}
+			if calls != len(test.responses) {
+				t.Errorf("countNodes() made %d list calls, want %d", calls, len(test.responses))
+			}
+		})
+	}
+}
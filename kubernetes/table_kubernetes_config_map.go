@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// ConfigMap is a row of the kubernetes_config_map table
+type ConfigMap struct {
+	v1.ConfigMap
+	sourceInfo
+}
+
 func tableKubernetesConfigMap(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_config_map",
-		Description: "Config Map can be used to store fine-grained information like individual properties or coarse-grained information like entire config files or JSON blobs.",
+		Name:              "kubernetes_config_map",
+		Description:       "Config Map can be used to store fine-grained information like individual properties or coarse-grained information like entire config files or JSON blobs.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sConfigMap,
 		},
 		List: &plugin.ListConfig{
@@ -65,6 +73,15 @@ func listK8sConfigMaps(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sConfigMaps")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newConfigMapRow, schema.GroupKind{Kind: "ConfigMap"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -96,8 +113,12 @@ func listK8sConfigMaps(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.CoreV1().ConfigMaps("").List(ctx, input)
+		response, err = clientset.CoreV1().ConfigMaps(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sConfigMaps, err)
+			}
 			return nil, err
 		}
 
@@ -108,7 +129,7 @@ func listK8sConfigMaps(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 		}
 
 		for _, configMap := range response.Items {
-			d.StreamListItem(ctx, configMap)
+			d.StreamListItem(ctx, ConfigMap{ConfigMap: configMap})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -124,6 +145,14 @@ func getK8sConfigMap(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sConfigMap")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newConfigMapRow, schema.GroupKind{Kind: "ConfigMap"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -142,12 +171,21 @@ func getK8sConfigMap(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		return nil, err
 	}
 
-	return *configMap, nil
+	return ConfigMap{ConfigMap: *configMap}, nil
+}
+
+func newConfigMapRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.ConfigMap
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return ConfigMap{ConfigMap: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformConfigMapTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.ConfigMap)
+	obj := d.HydrateItem.(ConfigMap)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
@@ -0,0 +1,252 @@
+package kubernetes
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// Container types, by the list of the pod spec the container is in. Sidecars are init
+// containers which keep running, i.e. with a restart policy of Always.
+const (
+	containerTypeRegular   = "regular"
+	containerTypeInit      = "init"
+	containerTypeSidecar   = "sidecar"
+	containerTypeEphemeral = "ephemeral"
+)
+
+// Container is a row of the kubernetes_container table
+type Container struct {
+	v1.Container
+	ContainerType       string
+	TargetContainerName string
+	podInfo
+	sourceInfo
+}
+
+func tableKubernetesContainer(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_container",
+		Description:       "The containers of every pod, including init, sidecar and ephemeral containers, with one row per container.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
+		List: &plugin.ListConfig{
+			Hydrate:    listK8sContainers,
+			KeyColumns: podKeyColumns(),
+		},
+		Columns: append(append(podObjectColumns(), []*plugin.Column{
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the container, unique within the pod.",
+			},
+			{
+				Name:        "container_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "Type of the container: regular, init, sidecar (an init container with a restart policy of Always) or ephemeral.",
+			},
+			{
+				Name:        "image",
+				Type:        proto.ColumnType_STRING,
+				Description: "Container image name.",
+			},
+			{
+				Name:        "image_pull_policy",
+				Type:        proto.ColumnType_STRING,
+				Description: "Image pull policy, one of Always, Never or IfNotPresent.",
+			},
+			{
+				Name:        "command",
+				Type:        proto.ColumnType_JSON,
+				Description: "Entrypoint array. Not executed within a shell. The image's ENTRYPOINT is used if this is not provided.",
+			},
+			{
+				Name:        "args",
+				Type:        proto.ColumnType_JSON,
+				Description: "Arguments to the entrypoint. The image's CMD is used if this is not provided.",
+			},
+			{
+				Name:        "working_dir",
+				Type:        proto.ColumnType_STRING,
+				Description: "Container's working directory.",
+			},
+			{
+				Name:        "ports",
+				Type:        proto.ColumnType_JSON,
+				Description: "List of ports to expose from the container.",
+			},
+			{
+				Name:        "env",
+				Type:        proto.ColumnType_JSON,
+				Description: "List of environment variables to set in the container.",
+			},
+			{
+				Name:        "env_from",
+				Type:        proto.ColumnType_JSON,
+				Description: "List of sources to populate environment variables in the container.",
+			},
+			{
+				Name:        "volume_mounts",
+				Type:        proto.ColumnType_JSON,
+				Description: "Pod volumes to mount into the container's filesystem.",
+			},
+			{
+				Name:        "volume_devices",
+				Type:        proto.ColumnType_JSON,
+				Description: "List of block devices to be used by the container.",
+			},
+			{
+				Name:        "liveness_probe",
+				Type:        proto.ColumnType_JSON,
+				Description: "Periodic probe of container liveness. The container is restarted if the probe fails.",
+			},
+			{
+				Name:        "readiness_probe",
+				Type:        proto.ColumnType_JSON,
+				Description: "Periodic probe of container service readiness. The container is removed from service endpoints if the probe fails.",
+			},
+			{
+				Name:        "startup_probe",
+				Type:        proto.ColumnType_JSON,
+				Description: "Probe that the container has successfully initialized. Other probes are not run until it succeeds.",
+			},
+			{
+				Name:        "lifecycle",
+				Type:        proto.ColumnType_JSON,
+				Description: "Actions that the management system should take in response to container lifecycle events.",
+			},
+			{
+				Name:        "resources",
+				Type:        proto.ColumnType_JSON,
+				Description: "Compute resources requested and limited for the container.",
+			},
+			{
+				Name:        "cpu_request",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "CPU requested by the container, in cores.",
+				Transform:   transform.FromP(transformContainerQuantity, containerQuantity{Name: v1.ResourceCPU}),
+			},
+			{
+				Name:        "cpu_limit",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "CPU limit of the container, in cores.",
+				Transform:   transform.FromP(transformContainerQuantity, containerQuantity{Limit: true, Name: v1.ResourceCPU}),
+			},
+			{
+				Name:        "memory_request",
+				Type:        proto.ColumnType_INT,
+				Description: "Memory requested by the container, in bytes.",
+				Transform:   transform.FromP(transformContainerQuantity, containerQuantity{Name: v1.ResourceMemory}),
+			},
+			{
+				Name:        "memory_limit",
+				Type:        proto.ColumnType_INT,
+				Description: "Memory limit of the container, in bytes.",
+				Transform:   transform.FromP(transformContainerQuantity, containerQuantity{Limit: true, Name: v1.ResourceMemory}),
+			},
+			{
+				Name:        "ephemeral_storage_request",
+				Type:        proto.ColumnType_INT,
+				Description: "Local ephemeral storage requested by the container, in bytes.",
+				Transform:   transform.FromP(transformContainerQuantity, containerQuantity{Name: v1.ResourceEphemeralStorage}),
+			},
+			{
+				Name:        "ephemeral_storage_limit",
+				Type:        proto.ColumnType_INT,
+				Description: "Local ephemeral storage limit of the container, in bytes.",
+				Transform:   transform.FromP(transformContainerQuantity, containerQuantity{Limit: true, Name: v1.ResourceEphemeralStorage}),
+			},
+			{
+				Name:        "security_context",
+				Type:        proto.ColumnType_JSON,
+				Description: "Security options the container should be run with.",
+			},
+			{
+				Name:        "privileged",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the container runs in privileged mode.",
+				Transform:   transform.FromField("SecurityContext.Privileged"),
+			},
+			{
+				Name:        "target_container_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "For ephemeral containers, the name of the container whose namespaces it targets.",
+				Transform:   transform.FromField("TargetContainerName").Transform(transform.NullIfZeroValue),
+			},
+		}...), append(kubectlConfigColumns(), sourceColumns()...)...),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sContainers(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sContainers")
+
+	return listPodObjects(ctx, d, listK8sContainers, streamPodContainers)
+}
+
+func streamPodContainers(ctx context.Context, d *plugin.QueryData, pod v1.Pod, object map[string]interface{}, source sourceInfo) error {
+	newRow := func(container v1.Container, containerType string) Container {
+		return Container{
+			Container:     container,
+			ContainerType: containerType,
+			podInfo:       newPodInfo(pod),
+			sourceInfo:    source,
+		}
+	}
+
+	initContainerObjects := podObjectContainers(object, "initContainers")
+	for i, container := range pod.Spec.InitContainers {
+		containerType := containerTypeInit
+		if i < len(initContainerObjects) && stringValue(initContainerObjects[i]["restartPolicy"]) == "Always" {
+			containerType = containerTypeSidecar
+		}
+		d.StreamListItem(ctx, newRow(container, containerType))
+	}
+
+	for _, container := range pod.Spec.Containers {
+		d.StreamListItem(ctx, newRow(container, containerTypeRegular))
+	}
+
+	for _, container := range pod.Spec.EphemeralContainers {
+		row := newRow(v1.Container(container.EphemeralContainerCommon), containerTypeEphemeral)
+		row.TargetContainerName = container.TargetContainerName
+		d.StreamListItem(ctx, row)
+	}
+
+	return nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+// containerQuantity is the transform param of a resource request or limit column
+type containerQuantity struct {
+	Limit bool
+	Name  v1.ResourceName
+}
+
+// transformContainerQuantity :: a request or limit of the container as a number. CPU is
+// returned in cores and other resources in their base unit, e.g. bytes of memory.
+func transformContainerQuantity(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(Container)
+	param := d.Param.(containerQuantity)
+
+	resources := obj.Resources.Requests
+	if param.Limit {
+		resources = obj.Resources.Limits
+	}
+
+	quantity, ok := resources[param.Name]
+	if !ok {
+		return nil, nil
+	}
+
+	if param.Name == v1.ResourceCPU {
+		return quantity.AsApproximateFloat64(), nil
+	}
+	return quantity.Value(), nil
+}
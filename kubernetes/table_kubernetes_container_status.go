@@ -0,0 +1,239 @@
+package kubernetes
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// ContainerStatus is a row of the kubernetes_container_status table
+type ContainerStatus struct {
+	v1.ContainerStatus
+	ContainerType string
+	podInfo
+	sourceInfo
+}
+
+func tableKubernetesContainerStatus(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_container_status",
+		Description:       "The status of the containers of every pod, including init, sidecar and ephemeral containers, with one row per container.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
+		List: &plugin.ListConfig{
+			Hydrate:    listK8sContainerStatuses,
+			KeyColumns: podKeyColumns(),
+		},
+		Columns: append(append(podObjectColumns(), []*plugin.Column{
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the container, unique within the pod.",
+			},
+			{
+				Name:        "container_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "Type of the container: regular, init, sidecar (an init container with a restart policy of Always) or ephemeral.",
+			},
+			{
+				Name:        "ready",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the container has passed its readiness probe.",
+			},
+			{
+				Name:        "started",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the container has passed its startup probe. Null if the kubelet has not reported it.",
+			},
+			{
+				Name:        "restart_count",
+				Type:        proto.ColumnType_INT,
+				Description: "Number of times the container has been restarted.",
+			},
+			{
+				Name:        "image",
+				Type:        proto.ColumnType_STRING,
+				Description: "Image the container is running.",
+			},
+			{
+				Name:        "image_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "ID of the image the container is running, usually including its digest.",
+				Transform:   transform.FromField("ImageID"),
+			},
+			{
+				Name:        "container_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "ID of the container, as <type>://<container_id>.",
+				Transform:   transform.FromField("ContainerID").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "state",
+				Type:        proto.ColumnType_STRING,
+				Description: "Current state of the container: waiting, running or terminated.",
+				Transform:   transform.From(transformContainerStatusState),
+			},
+			{
+				Name:        "state_reason",
+				Type:        proto.ColumnType_STRING,
+				Description: "Reason the container is waiting or terminated, e.g. CrashLoopBackOff or OOMKilled.",
+				Transform:   transform.From(transformContainerStatusStateReason),
+			},
+			{
+				Name:        "state_message",
+				Type:        proto.ColumnType_STRING,
+				Description: "Message about why the container is waiting or terminated.",
+				Transform:   transform.From(transformContainerStatusStateMessage),
+			},
+			{
+				Name:        "state_started_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time the container was last started, if it is running or terminated.",
+				Transform:   transform.From(transformContainerStatusStateStartedAt).Transform(v1TimeToRFC3339),
+			},
+			{
+				Name:        "state_exit_code",
+				Type:        proto.ColumnType_INT,
+				Description: "Exit code of the container, if it is terminated.",
+				Transform:   transform.FromField("State.Terminated.ExitCode"),
+			},
+			{
+				Name:        "last_termination_reason",
+				Type:        proto.ColumnType_STRING,
+				Description: "Reason the container last terminated, e.g. OOMKilled or Error.",
+				Transform:   transform.FromField("LastTerminationState.Terminated.Reason"),
+			},
+			{
+				Name:        "last_termination_message",
+				Type:        proto.ColumnType_STRING,
+				Description: "Message about why the container last terminated.",
+				Transform:   transform.FromField("LastTerminationState.Terminated.Message"),
+			},
+			{
+				Name:        "last_termination_exit_code",
+				Type:        proto.ColumnType_INT,
+				Description: "Exit code of the last termination of the container.",
+				Transform:   transform.FromField("LastTerminationState.Terminated.ExitCode"),
+			},
+			{
+				Name:        "last_termination_signal",
+				Type:        proto.ColumnType_INT,
+				Description: "Signal of the last termination of the container.",
+				Transform:   transform.FromField("LastTerminationState.Terminated.Signal"),
+			},
+			{
+				Name:        "last_termination_started_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time the container was started before its last termination.",
+				Transform:   transform.FromField("LastTerminationState.Terminated.StartedAt").Transform(v1TimeToRFC3339),
+			},
+			{
+				Name:        "last_termination_finished_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time the container last terminated.",
+				Transform:   transform.FromField("LastTerminationState.Terminated.FinishedAt").Transform(v1TimeToRFC3339),
+			},
+		}...), append(kubectlConfigColumns(), sourceColumns()...)...),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sContainerStatuses(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sContainerStatuses")
+
+	return listPodObjects(ctx, d, listK8sContainerStatuses, streamPodContainerStatuses)
+}
+
+func streamPodContainerStatuses(ctx context.Context, d *plugin.QueryData, pod v1.Pod, object map[string]interface{}, source sourceInfo) error {
+	newRow := func(status v1.ContainerStatus, containerType string) ContainerStatus {
+		return ContainerStatus{
+			ContainerStatus: status,
+			ContainerType:   containerType,
+			podInfo:         newPodInfo(pod),
+			sourceInfo:      source,
+		}
+	}
+
+	// sidecars are found by the restart policy of their init container in the spec
+	sidecars := map[string]bool{}
+	for _, container := range podObjectContainers(object, "initContainers") {
+		if stringValue(container["restartPolicy"]) == "Always" {
+			sidecars[stringValue(container["name"])] = true
+		}
+	}
+
+	for _, status := range pod.Status.InitContainerStatuses {
+		containerType := containerTypeInit
+		if sidecars[status.Name] {
+			containerType = containerTypeSidecar
+		}
+		d.StreamListItem(ctx, newRow(status, containerType))
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		d.StreamListItem(ctx, newRow(status, containerTypeRegular))
+	}
+
+	for _, status := range pod.Status.EphemeralContainerStatuses {
+		d.StreamListItem(ctx, newRow(status, containerTypeEphemeral))
+	}
+
+	return nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func transformContainerStatusState(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	state := d.HydrateItem.(ContainerStatus).State
+
+	switch {
+	case state.Waiting != nil:
+		return "waiting", nil
+	case state.Running != nil:
+		return "running", nil
+	case state.Terminated != nil:
+		return "terminated", nil
+	}
+	return nil, nil
+}
+
+func transformContainerStatusStateReason(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	state := d.HydrateItem.(ContainerStatus).State
+
+	switch {
+	case state.Waiting != nil:
+		return state.Waiting.Reason, nil
+	case state.Terminated != nil:
+		return state.Terminated.Reason, nil
+	}
+	return nil, nil
+}
+
+func transformContainerStatusStateMessage(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	state := d.HydrateItem.(ContainerStatus).State
+
+	switch {
+	case state.Waiting != nil:
+		return state.Waiting.Message, nil
+	case state.Terminated != nil:
+		return state.Terminated.Message, nil
+	}
+	return nil, nil
+}
+
+func transformContainerStatusStateStartedAt(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	state := d.HydrateItem.(ContainerStatus).State
+
+	switch {
+	case state.Running != nil:
+		return state.Running.StartedAt, nil
+	case state.Terminated != nil:
+		return state.Terminated.StartedAt, nil
+	}
+	return nil, nil
+}
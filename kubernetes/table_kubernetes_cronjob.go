@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// CronJob is a row of the kubernetes_cronjob table
+type CronJob struct {
+	v1.CronJob
+	sourceInfo
+}
+
 func tableKubernetesCronJob(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_cronjob",
-		Description: "Cron jobs are useful for creating periodic and recurring tasks, like running backups or sending emails.",
+		Name:              "kubernetes_cronjob",
+		Description:       "Cron jobs are useful for creating periodic and recurring tasks, like running backups or sending emails.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sCronJob,
 		},
 		List: &plugin.ListConfig{
@@ -112,6 +120,15 @@ func listK8sCronJobs(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sCronJobs")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newCronJobRow, schema.GroupKind{Group: "batch", Kind: "CronJob"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -142,8 +159,12 @@ func listK8sCronJobs(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	var response *v1.CronJobList
 	pageLeft := true
 	for pageLeft {
-		response, err = clientset.BatchV1().CronJobs("").List(ctx, input)
+		response, err = clientset.BatchV1().CronJobs(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sCronJobs, err)
+			}
 			logger.Error("listK8sCronJobs", "list_err", err)
 			return nil, err
 		}
@@ -155,7 +176,7 @@ func listK8sCronJobs(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		}
 
 		for _, cronJob := range response.Items {
-			d.StreamListItem(ctx, cronJob)
+			d.StreamListItem(ctx, CronJob{CronJob: cronJob})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -171,6 +192,14 @@ func getK8sCronJob(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDa
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sCronJob")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newCronJobRow, schema.GroupKind{Group: "batch", Kind: "CronJob"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -190,12 +219,21 @@ func getK8sCronJob(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDa
 		return nil, err
 	}
 
-	return *cronJob, nil
+	return CronJob{CronJob: *cronJob}, nil
+}
+
+func newCronJobRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.CronJob
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return CronJob{CronJob: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformCronJobTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.CronJob)
+	obj := d.HydrateItem.(CronJob)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
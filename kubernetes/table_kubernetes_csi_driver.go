@@ -0,0 +1,214 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+
+	v1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// CSIDriver is a row of the kubernetes_csi_driver table
+type CSIDriver struct {
+	v1.CSIDriver
+	sourceInfo
+}
+
+func tableKubernetesCSIDriver(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_csi_driver",
+		Description:       "A CSIDriver describes a Container Storage Interface (CSI) volume driver installed in the cluster, and how Kubernetes should interact with it.",
+		GetMatrixItemFunc: BuildContextList,
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
+			Hydrate:    getK8sCSIDriver,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listK8sCSIDrivers,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
+		},
+		Columns: k8sCommonGlobalColumns([]*plugin.Column{
+			{
+				Name:        "attach_required",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if volumes of the driver need an attach operation, so Kubernetes creates a VolumeAttachment and waits for it before mounting.",
+				Transform:   transform.FromField("Spec.AttachRequired"),
+			},
+			{
+				Name:        "pod_info_on_mount",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the driver is passed information about the pod, such as its name and namespace, when mounting a volume.",
+				Transform:   transform.FromField("Spec.PodInfoOnMount"),
+			},
+			{
+				Name:        "volume_lifecycle_modes",
+				Type:        proto.ColumnType_JSON,
+				Description: "Volume modes the driver supports: Persistent, Ephemeral or both.",
+				Transform:   transform.FromField("Spec.VolumeLifecycleModes"),
+			},
+			{
+				Name:        "storage_capacity",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the scheduler takes the storage capacity reported by the driver into account.",
+				Transform:   transform.FromField("Spec.StorageCapacity"),
+			},
+			{
+				Name:        "fs_group_policy",
+				Type:        proto.ColumnType_STRING,
+				Description: "Whether the driver supports changing the ownership and permissions of volumes to the fsGroup of the pod: ReadWriteOnceWithFSType, File or None.",
+				Transform:   transform.FromField("Spec.FSGroupPolicy"),
+			},
+			{
+				Name:        "token_requests",
+				Type:        proto.ColumnType_JSON,
+				Description: "Audiences of the service account tokens passed to the driver when mounting a volume.",
+				Transform:   transform.FromField("Spec.TokenRequests"),
+			},
+			{
+				Name:        "requires_republish",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the driver is called periodically to republish volumes, e.g. to refresh tokens.",
+				Transform:   transform.FromField("Spec.RequiresRepublish"),
+			},
+			{
+				Name:        "se_linux_mount",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the driver supports mounting volumes with the SELinux context of the pod.",
+				Transform:   transform.FromField("Spec.SELinuxMount"),
+			},
+
+			//// Steampipe Standard Columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: ColumnDescriptionTitle,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Type:        proto.ColumnType_JSON,
+				Description: ColumnDescriptionTags,
+				Transform:   transform.From(transformCSIDriverTags),
+			},
+		}),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sCSIDrivers(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sCSIDrivers")
+
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newCSIDriverRow, schema.GroupKind{Group: "storage.k8s.io", Kind: "CSIDriver"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	// Limiting the results
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < input.Limit {
+			if *limit < 1 {
+				input.Limit = 1
+			} else {
+				input.Limit = *limit
+			}
+		}
+	}
+
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
+	var response *v1.CSIDriverList
+	pageLeft := true
+
+	for pageLeft {
+		response, err = clientset.StorageV1().CSIDrivers().List(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+		} else {
+			pageLeft = false
+		}
+
+		for _, csiDriver := range response.Items {
+			d.StreamListItem(ctx, CSIDriver{CSIDriver: csiDriver})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getK8sCSIDriver(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("getK8sCSIDriver")
+
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newCSIDriverRow, schema.GroupKind{Group: "storage.k8s.io", Kind: "CSIDriver"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	name := d.KeyColumnQuals["name"].GetStringValue()
+
+	// return if name is empty
+	if name == "" {
+		return nil, nil
+	}
+
+	csiDriver, err := clientset.StorageV1().CSIDrivers().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return CSIDriver{CSIDriver: *csiDriver}, nil
+}
+
+func newCSIDriverRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.CSIDriver
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return CSIDriver{CSIDriver: obj, sourceInfo: manifest.sourceInfo}, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func transformCSIDriverTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(CSIDriver)
+	return mergeTags(obj.Labels, obj.Annotations), nil
+}
@@ -0,0 +1,188 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+
+	v1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// CSINode is a row of the kubernetes_csi_node table
+type CSINode struct {
+	v1.CSINode
+	sourceInfo
+}
+
+func tableKubernetesCSINode(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_csi_node",
+		Description:       "A CSINode holds information about the Container Storage Interface (CSI) drivers installed on a node. It has the same name as the node.",
+		GetMatrixItemFunc: BuildContextList,
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
+			Hydrate:    getK8sCSINode,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listK8sCSINodes,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
+		},
+		Columns: k8sCommonGlobalColumns([]*plugin.Column{
+			{
+				Name:        "drivers",
+				Type:        proto.ColumnType_JSON,
+				Description: "CSI drivers registered on the node, with the ID of the node in each driver, its topology keys and the number of volumes it can attach.",
+				Transform:   transform.FromField("Spec.Drivers"),
+			},
+			{
+				Name:        "driver_names",
+				Type:        proto.ColumnType_JSON,
+				Description: "Names of the CSI drivers registered on the node.",
+				Transform:   transform.From(transformCSINodeDriverNames),
+			},
+
+			//// Steampipe Standard Columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: ColumnDescriptionTitle,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Type:        proto.ColumnType_JSON,
+				Description: ColumnDescriptionTags,
+				Transform:   transform.From(transformCSINodeTags),
+			},
+		}),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sCSINodes(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sCSINodes")
+
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newCSINodeRow, schema.GroupKind{Group: "storage.k8s.io", Kind: "CSINode"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	// Limiting the results
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < input.Limit {
+			if *limit < 1 {
+				input.Limit = 1
+			} else {
+				input.Limit = *limit
+			}
+		}
+	}
+
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
+	var response *v1.CSINodeList
+	pageLeft := true
+
+	for pageLeft {
+		response, err = clientset.StorageV1().CSINodes().List(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+		} else {
+			pageLeft = false
+		}
+
+		for _, csiNode := range response.Items {
+			d.StreamListItem(ctx, CSINode{CSINode: csiNode})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getK8sCSINode(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("getK8sCSINode")
+
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newCSINodeRow, schema.GroupKind{Group: "storage.k8s.io", Kind: "CSINode"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	name := d.KeyColumnQuals["name"].GetStringValue()
+
+	// return if name is empty
+	if name == "" {
+		return nil, nil
+	}
+
+	csiNode, err := clientset.StorageV1().CSINodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return CSINode{CSINode: *csiNode}, nil
+}
+
+func newCSINodeRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.CSINode
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return CSINode{CSINode: obj, sourceInfo: manifest.sourceInfo}, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func transformCSINodeDriverNames(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(CSINode)
+
+	names := []string{}
+	for _, driver := range obj.Spec.Drivers {
+		names = append(names, driver.Name)
+	}
+	return names, nil
+}
+
+func transformCSINodeTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(CSINode)
+	return mergeTags(obj.Labels, obj.Annotations), nil
+}
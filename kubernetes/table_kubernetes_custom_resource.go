@@ -0,0 +1,362 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// CustomResource is a row of a table generated for a CustomResourceDefinition, or of
+// the kubernetes_resource table. Spec and Status hold the object's unstructured content.
+type CustomResource struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	Spec   interface{}
+	Status interface{}
+	Object map[string]interface{}
+	sourceInfo
+}
+
+// customResourceTable describes the table generated for a CustomResourceDefinition
+type customResourceTable struct {
+	Name       string
+	Definition apiextensionsv1.CustomResourceDefinition
+	Version    apiextensionsv1.CustomResourceDefinitionVersion
+}
+
+func (t customResourceTable) groupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    t.Definition.Spec.Group,
+		Version:  t.Version.Name,
+		Resource: t.Definition.Spec.Names.Plural,
+	}
+}
+
+func (t customResourceTable) groupKind() schema.GroupKind {
+	return schema.GroupKind{Group: t.Definition.Spec.Group, Kind: t.Definition.Spec.Names.Kind}
+}
+
+func (t customResourceTable) namespaced() bool {
+	return t.Definition.Spec.Scope == apiextensionsv1.NamespaceScoped
+}
+
+func tableKubernetesCustomResource(ctx context.Context, crdTable customResourceTable) *plugin.Table {
+	table := &plugin.Table{
+		Name:        crdTable.Name,
+		Description: fmt.Sprintf("Custom resources of kind %s (%s).", crdTable.Definition.Spec.Names.Kind, crdTable.Definition.Name),
+		List: &plugin.ListConfig{
+			Hydrate: listK8sCustomResources(crdTable),
+		},
+	}
+
+	if crdTable.namespaced() {
+		table.GetMatrixItemFunc = BuildContextNamespaceList
+		table.Get = &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
+			Hydrate:    getK8sCustomResource(crdTable),
+		}
+		table.List.KeyColumns = getCommonOptionalKeyQuals()
+		table.Columns = k8sCommonColumns(customResourceColumns(crdTable, k8sCommonColumns(nil)))
+	} else {
+		table.GetMatrixItemFunc = BuildContextList
+		table.Get = &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
+			Hydrate:    getK8sCustomResource(crdTable),
+		}
+		table.List.KeyColumns = []*plugin.KeyColumn{
+			{Name: "name", Require: plugin.Optional},
+		}
+		table.Columns = k8sCommonGlobalColumns(customResourceColumns(crdTable, k8sCommonGlobalColumns(nil)))
+	}
+
+	return table
+}
+
+// customResourceColumns :: a typed column per top-level property of the spec and status
+// in the OpenAPI v3 schema of the CRD version. Properties clashing with another column
+// are prefixed with spec_ or status_.
+func customResourceColumns(crdTable customResourceTable, commonColumns []*plugin.Column) []*plugin.Column {
+	columns := []*plugin.Column{
+		{
+			Name:        "spec",
+			Type:        proto.ColumnType_JSON,
+			Description: "Spec of the custom resource.",
+		},
+		{
+			Name:        "status",
+			Type:        proto.ColumnType_JSON,
+			Description: "Status of the custom resource.",
+		},
+	}
+
+	//// Steampipe Standard Columns
+	standardColumns := []*plugin.Column{
+		{
+			Name:        "title",
+			Type:        proto.ColumnType_STRING,
+			Description: ColumnDescriptionTitle,
+			Transform:   transform.FromField("Name"),
+		},
+		{
+			Name:        "tags",
+			Type:        proto.ColumnType_JSON,
+			Description: ColumnDescriptionTags,
+			Transform:   transform.From(transformCustomResourceTags),
+		},
+	}
+
+	reserved := map[string]bool{}
+	for _, column := range append(append(commonColumns, columns...), standardColumns...) {
+		reserved[column.Name] = true
+	}
+
+	var schemaProps *apiextensionsv1.JSONSchemaProps
+	if crdTable.Version.Schema != nil {
+		schemaProps = crdTable.Version.Schema.OpenAPIV3Schema
+	}
+
+	for _, field := range []string{"spec", "status"} {
+		if schemaProps == nil {
+			break
+		}
+		fieldProps, ok := schemaProps.Properties[field]
+		if !ok {
+			continue
+		}
+
+		propertyNames := make([]string, 0, len(fieldProps.Properties))
+		for propertyName := range fieldProps.Properties {
+			propertyNames = append(propertyNames, propertyName)
+		}
+		sort.Strings(propertyNames)
+
+		for _, propertyName := range propertyNames {
+			property := fieldProps.Properties[propertyName]
+
+			columnName := toColumnName(propertyName)
+			if reserved[columnName] {
+				columnName = field + "_" + columnName
+			}
+			if reserved[columnName] {
+				continue
+			}
+			reserved[columnName] = true
+
+			description := strings.TrimSpace(property.Description)
+			if description == "" {
+				description = fmt.Sprintf("The %s property of the %s.", propertyName, field)
+			}
+
+			columns = append(columns, &plugin.Column{
+				Name:        columnName,
+				Type:        jsonSchemaColumnType(property),
+				Description: description,
+				Transform:   transform.FromP(getCustomResourceProperty, []string{field, propertyName}),
+			})
+		}
+	}
+
+	return append(columns, standardColumns...)
+}
+
+// jsonSchemaColumnType :: column type for a property of an OpenAPI v3 schema
+func jsonSchemaColumnType(property apiextensionsv1.JSONSchemaProps) proto.ColumnType {
+	if property.XIntOrString {
+		return proto.ColumnType_JSON
+	}
+
+	switch property.Type {
+	case "string":
+		if property.Format == "date-time" {
+			return proto.ColumnType_TIMESTAMP
+		}
+		return proto.ColumnType_STRING
+	case "integer":
+		return proto.ColumnType_INT
+	case "number":
+		return proto.ColumnType_DOUBLE
+	case "boolean":
+		return proto.ColumnType_BOOL
+	}
+
+	return proto.ColumnType_JSON
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sCustomResources(crdTable customResourceTable) plugin.HydrateFunc {
+	var listFunc plugin.HydrateFunc
+	listFunc = func(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+		logger := plugin.Logger(ctx)
+		logger.Trace("listK8sCustomResources", "table", crdTable.Name)
+
+		if isManifestSource(d) {
+			return nil, streamManifestObjects(ctx, d, newCustomResourceRow, crdTable.groupKind())
+		}
+
+		namespace, ok, err := getQueryNamespace(ctx, d)
+		if err != nil || !ok {
+			return nil, err
+		}
+
+		client, err := GetNewClientDynamic(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+
+		input := metav1.ListOptions{
+			Limit: 500,
+		}
+
+		// Limiting the results
+		limit := d.QueryContext.Limit
+		if d.QueryContext.Limit != nil {
+			if *limit < input.Limit {
+				if *limit < 1 {
+					input.Limit = 1
+				} else {
+					input.Limit = *limit
+				}
+			}
+		}
+
+		commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+		if len(commonFieldSelectorValue) > 0 {
+			input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+		}
+
+		resource := client.Resource(crdTable.groupVersionResource())
+
+		pageLeft := true
+		for pageLeft {
+			response, err := resource.Namespace(namespace).List(ctx, input)
+			if err != nil {
+				// fall back to the namespaces the caller can access
+				if crdTable.namespaced() && isClusterListForbidden(err, namespace, input) {
+					return listAccessibleNamespaces(ctx, d, listFunc, err)
+				}
+				// the CRD is not installed in every context of the connection
+				if isNotFoundError(err) {
+					return nil, nil
+				}
+				return nil, err
+			}
+
+			if response.GetContinue() != "" {
+				input.Continue = response.GetContinue()
+			} else {
+				pageLeft = false
+			}
+
+			for _, item := range response.Items {
+				row, err := newCustomResource(item.Object, sourceInfo{})
+				if err != nil {
+					return nil, err
+				}
+				d.StreamListItem(ctx, row)
+
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if d.QueryStatus.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+		}
+
+		return nil, nil
+	}
+
+	return listFunc
+}
+
+func getK8sCustomResource(crdTable customResourceTable) plugin.HydrateFunc {
+	return func(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+		logger := plugin.Logger(ctx)
+		logger.Trace("getK8sCustomResource", "table", crdTable.Name)
+
+		if isManifestSource(d) {
+			return getManifestObject(ctx, d, newCustomResourceRow, crdTable.groupKind())
+		}
+
+		if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+			return nil, err
+		}
+
+		name := d.KeyColumnQuals["name"].GetStringValue()
+		namespace := d.KeyColumnQuals["namespace"].GetStringValue()
+
+		// return if name is empty, or namespace for a namespaced resource
+		if name == "" || (crdTable.namespaced() && namespace == "") {
+			return nil, nil
+		}
+
+		client, err := GetNewClientDynamic(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+
+		item, err := client.Resource(crdTable.groupVersionResource()).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if isNotFoundError(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return newCustomResource(item.Object, sourceInfo{})
+	}
+}
+
+func newCustomResourceRow(manifest manifestObject) (interface{}, error) {
+	return newCustomResource(manifest.Object, manifest.sourceInfo)
+}
+
+// newCustomResource :: row for an unstructured object
+func newCustomResource(object map[string]interface{}, source sourceInfo) (CustomResource, error) {
+	row := CustomResource{
+		Spec:       object["spec"],
+		Status:     object["status"],
+		Object:     object,
+		sourceInfo: source,
+	}
+	row.APIVersion = stringValue(object["apiVersion"])
+	row.Kind = stringValue(object["kind"])
+
+	if metadata, ok := object["metadata"].(map[string]interface{}); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(metadata, &row.ObjectMeta); err != nil {
+			return row, err
+		}
+	}
+
+	return row, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+// getCustomResourceProperty :: value of a top-level property of the spec or status, given as the transform param
+func getCustomResourceProperty(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(CustomResource)
+	path := d.Param.([]string)
+
+	field, ok := obj.Object[path[0]].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	return field[path[1]], nil
+}
+
+func transformCustomResourceTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(CustomResource)
+	return mergeTags(obj.Labels, obj.Annotations), nil
+}
@@ -2,23 +2,36 @@ package kubernetes
 
 import (
 	"context"
+	"strings"
 
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 )
 
+// CustomResourceDefinition is a row of the kubernetes_custom_resource_definition table
+type CustomResourceDefinition struct {
+	v1.CustomResourceDefinition
+	sourceInfo
+}
+
 func tableKubernetesCustomResourceDefinition(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_custom_resource_definition",
-		Description: "Kubernetes Custom Resource Definition.",
+		Name:              "kubernetes_custom_resource_definition",
+		Description:       "Kubernetes Custom Resource Definition.",
+		GetMatrixItemFunc: BuildContextList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.SingleColumn("name"),
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
 			Hydrate:    getK8sCustomResourceDefinition,
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listK8sCustomResourceDefinitions,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
 		},
 		Columns: k8sCommonColumns([]*plugin.Column{
 			//// Resource definition specification
@@ -39,6 +52,10 @@ func tableKubernetesCustomResourceDefinition(ctx context.Context) *plugin.Table
 //// HYDRATE FUNCTIONS
 
 func listK8sCustomResourceDefinitions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newCustomResourceDefinitionRow, schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"})
+	}
+
 	clientset, err := GetNewClientCRD(ctx, d)
 	if err != nil {
 		plugin.Logger(ctx).Error("listK8sCustomResourceDefinitions", "connection_error", err)
@@ -61,6 +78,12 @@ func listK8sCustomResourceDefinitions(ctx context.Context, d *plugin.QueryData,
 		}
 	}
 
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
 	pageLeft := true
 	for pageLeft {
 		response, err := clientset.ApiextensionsV1().CustomResourceDefinitions().List(ctx, input)
@@ -76,7 +99,7 @@ func listK8sCustomResourceDefinitions(ctx context.Context, d *plugin.QueryData,
 		}
 
 		for _, crd := range response.Items {
-			d.StreamListItem(ctx, crd)
+			d.StreamListItem(ctx, CustomResourceDefinition{CustomResourceDefinition: crd})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -96,6 +119,10 @@ func getK8sCustomResourceDefinition(ctx context.Context, d *plugin.QueryData, _
 		return nil, nil
 	}
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newCustomResourceDefinitionRow, schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"})
+	}
+
 	clientset, err := GetNewClientCRD(ctx, d)
 	if err != nil {
 		plugin.Logger(ctx).Error("getK8sCustomResourceDefinition", "connection_err", err)
@@ -108,5 +135,14 @@ func getK8sCustomResourceDefinition(ctx context.Context, d *plugin.QueryData, _
 		return nil, err
 	}
 
-	return response, nil
+	return CustomResourceDefinition{CustomResourceDefinition: *response}, nil
+}
+
+func newCustomResourceDefinitionRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.CustomResourceDefinition
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return CustomResourceDefinition{CustomResourceDefinition: obj, sourceInfo: manifest.sourceInfo}, nil
 }
@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// DaemonSet is a row of the kubernetes_daemonset table
+type DaemonSet struct {
+	v1.DaemonSet
+	sourceInfo
+}
+
 func tableKubernetesDaemonset(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_daemonset",
-		Description: "A DaemonSet ensures that all (or some) Nodes run a copy of a Pod.",
+		Name:              "kubernetes_daemonset",
+		Description:       "A DaemonSet ensures that all (or some) Nodes run a copy of a Pod.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sDaemonSet,
 		},
 		List: &plugin.ListConfig{
@@ -149,6 +157,15 @@ func listK8sDaemonSets(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sDaemonSets")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newDaemonSetRow, schema.GroupKind{Group: "apps", Kind: "DaemonSet"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -180,8 +197,12 @@ func listK8sDaemonSets(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.AppsV1().DaemonSets("").List(ctx, input)
+		response, err = clientset.AppsV1().DaemonSets(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sDaemonSets, err)
+			}
 			return nil, err
 		}
 
@@ -192,7 +213,7 @@ func listK8sDaemonSets(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 		}
 
 		for _, daemonSet := range response.Items {
-			d.StreamListItem(ctx, daemonSet)
+			d.StreamListItem(ctx, DaemonSet{DaemonSet: daemonSet})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -208,6 +229,14 @@ func getK8sDaemonSet(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sDaemonSet")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newDaemonSetRow, schema.GroupKind{Group: "apps", Kind: "DaemonSet"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -226,12 +255,21 @@ func getK8sDaemonSet(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		return nil, err
 	}
 
-	return *daemonSet, nil
+	return DaemonSet{DaemonSet: *daemonSet}, nil
+}
+
+func newDaemonSetRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.DaemonSet
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return DaemonSet{DaemonSet: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformDaemonSetTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.DaemonSet)
+	obj := d.HydrateItem.(DaemonSet)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// Deployment is a row of the kubernetes_deployment table
+type Deployment struct {
+	v1.Deployment
+	sourceInfo
+}
+
 func tableKubernetesDeployment(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_deployment",
-		Description: "Kubernetes Deployment enables declarative updates for Pods and ReplicaSets.",
+		Name:              "kubernetes_deployment",
+		Description:       "Kubernetes Deployment enables declarative updates for Pods and ReplicaSets.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sDeployment,
 		},
 		List: &plugin.ListConfig{
@@ -155,6 +163,15 @@ func listK8sDeployments(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sDeployments")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newDeploymentRow, schema.GroupKind{Group: "apps", Kind: "Deployment"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -187,8 +204,12 @@ func listK8sDeployments(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 
 	for pageLeft {
 
-		response, err = clientset.AppsV1().Deployments("").List(ctx, input)
+		response, err = clientset.AppsV1().Deployments(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sDeployments, err)
+			}
 			return nil, err
 		}
 
@@ -199,7 +220,7 @@ func listK8sDeployments(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		}
 
 		for _, item := range response.Items {
-			d.StreamListItem(ctx, item)
+			d.StreamListItem(ctx, Deployment{Deployment: item})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -215,6 +236,14 @@ func getK8sDeployment(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrat
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sDeployment")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newDeploymentRow, schema.GroupKind{Group: "apps", Kind: "Deployment"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -233,12 +262,21 @@ func getK8sDeployment(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrat
 		return nil, err
 	}
 
-	return *deployment, nil
+	return Deployment{Deployment: *deployment}, nil
+}
+
+func newDeploymentRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.Deployment
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return Deployment{Deployment: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformDeploymentTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.Deployment)
+	obj := d.HydrateItem.(Deployment)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
@@ -6,18 +6,26 @@ import (
 
 	"k8s.io/api/discovery/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// EndpointSlice is a row of the kubernetes_endpoint_slice table
+type EndpointSlice struct {
+	v1beta1.EndpointSlice
+	sourceInfo
+}
+
 func tableKubernetesEndpointSlice(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_endpoint_slice",
-		Description: "EndpointSlice represents a subset of the endpoints that implement a service.",
+		Name:              "kubernetes_endpoint_slice",
+		Description:       "EndpointSlice represents a subset of the endpoints that implement a service.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sEnpointSlice,
 		},
 		List: &plugin.ListConfig{
@@ -64,6 +72,15 @@ func listK8sEnpointSlices(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sEnpointSlices")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newEndpointSliceRow, schema.GroupKind{Group: "discovery.k8s.io", Kind: "EndpointSlice"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -95,8 +112,12 @@ func listK8sEnpointSlices(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.DiscoveryV1beta1().EndpointSlices("").List(ctx, input)
+		response, err = clientset.DiscoveryV1beta1().EndpointSlices(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sEnpointSlices, err)
+			}
 			return nil, err
 		}
 
@@ -107,7 +128,7 @@ func listK8sEnpointSlices(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 		}
 
 		for _, endpointSlice := range response.Items {
-			d.StreamListItem(ctx, endpointSlice)
+			d.StreamListItem(ctx, EndpointSlice{EndpointSlice: endpointSlice})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -123,6 +144,14 @@ func getK8sEnpointSlice(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sEnpointSlice")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newEndpointSliceRow, schema.GroupKind{Group: "discovery.k8s.io", Kind: "EndpointSlice"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -141,12 +170,21 @@ func getK8sEnpointSlice(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		return nil, err
 	}
 
-	return *endpointSlice, nil
+	return EndpointSlice{EndpointSlice: *endpointSlice}, nil
+}
+
+func newEndpointSliceRow(manifest manifestObject) (interface{}, error) {
+	var obj v1beta1.EndpointSlice
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return EndpointSlice{EndpointSlice: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformEndpointSliceTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1beta1.EndpointSlice)
+	obj := d.HydrateItem.(EndpointSlice)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
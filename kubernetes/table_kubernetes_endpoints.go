@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// Endpoints is a row of the kubernetes_endpoints table
+type Endpoints struct {
+	v1.Endpoints
+	sourceInfo
+}
+
 func tableKubernetesEndpoints(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_endpoint",
-		Description: "Set of addresses and ports that comprise a service. More info: https://kubernetes.io/docs/concepts/services-networking/service/#services-without-selectors.",
+		Name:              "kubernetes_endpoint",
+		Description:       "Set of addresses and ports that comprise a service. More info: https://kubernetes.io/docs/concepts/services-networking/service/#services-without-selectors.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sEndpoint,
 		},
 		List: &plugin.ListConfig{
@@ -54,6 +62,15 @@ func listK8sEnpoints(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sEnpoints")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newEndpointsRow, schema.GroupKind{Kind: "Endpoints"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -85,8 +102,12 @@ func listK8sEnpoints(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.CoreV1().Endpoints("").List(ctx, input)
+		response, err = clientset.CoreV1().Endpoints(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sEnpoints, err)
+			}
 			return nil, err
 		}
 
@@ -97,7 +118,7 @@ func listK8sEnpoints(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		}
 
 		for _, endpoint := range response.Items {
-			d.StreamListItem(ctx, endpoint)
+			d.StreamListItem(ctx, Endpoints{Endpoints: endpoint})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -113,6 +134,14 @@ func getK8sEndpoint(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sEndpoint")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newEndpointsRow, schema.GroupKind{Kind: "Endpoints"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -131,12 +160,21 @@ func getK8sEndpoint(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 		return nil, err
 	}
 
-	return *endpoint, nil
+	return Endpoints{Endpoints: *endpoint}, nil
+}
+
+func newEndpointsRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.Endpoints
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return Endpoints{Endpoints: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformEndpointTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.Endpoints)
+	obj := d.HydrateItem.(Endpoints)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
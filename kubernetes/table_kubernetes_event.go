@@ -0,0 +1,457 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// Event is a row of the kubernetes_event table. Events read from the core v1 API are
+// converted to their events.k8s.io/v1 form.
+type Event struct {
+	eventsv1.Event
+	sourceInfo
+}
+
+func tableKubernetesEvent(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_event",
+		Description:       "Kubernetes Event is a report of an event somewhere in the cluster, such as a pod being scheduled or a container failing to start.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
+			Hydrate:    getK8sEvent,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listK8sEvents,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "involved_object_kind", Require: plugin.Optional},      // regarding.kind
+				{Name: "involved_object_name", Require: plugin.Optional},      // regarding.name
+				{Name: "involved_object_namespace", Require: plugin.Optional}, // regarding.namespace
+				{Name: "involved_object_uid", Require: plugin.Optional},       // regarding.uid
+				{Name: "reason", Require: plugin.Optional},                    // reason
+				{Name: "type", Require: plugin.Optional},                      // type
+				{Name: "name", Require: plugin.Optional},
+				{Name: "namespace", Require: plugin.Optional},
+			},
+		},
+		Columns: k8sCommonColumns([]*plugin.Column{
+			{
+				Name:        "involved_object_kind",
+				Type:        proto.ColumnType_STRING,
+				Description: "Kind of the object this event is about.",
+				Transform:   transform.FromField("Regarding.Kind"),
+			},
+			{
+				Name:        "involved_object_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the object this event is about.",
+				Transform:   transform.FromField("Regarding.Name"),
+			},
+			{
+				Name:        "involved_object_namespace",
+				Type:        proto.ColumnType_STRING,
+				Description: "Namespace of the object this event is about.",
+				Transform:   transform.FromField("Regarding.Namespace"),
+			},
+			{
+				Name:        "involved_object_uid",
+				Type:        proto.ColumnType_STRING,
+				Description: "UID of the object this event is about.",
+				Transform:   transform.FromField("Regarding.UID"),
+			},
+			{
+				Name:        "involved_object_api_version",
+				Type:        proto.ColumnType_STRING,
+				Description: "API version of the object this event is about.",
+				Transform:   transform.FromField("Regarding.APIVersion"),
+			},
+			{
+				Name:        "involved_object_field_path",
+				Type:        proto.ColumnType_STRING,
+				Description: "Part of the object this event is about, e.g. spec.containers{web} for a container of a pod.",
+				Transform:   transform.FromField("Regarding.FieldPath"),
+			},
+			{
+				Name:        "reason",
+				Type:        proto.ColumnType_STRING,
+				Description: "Why the action was taken, in a short machine understandable form, e.g. FailedScheduling.",
+			},
+			{
+				Name:        "type",
+				Type:        proto.ColumnType_STRING,
+				Description: "Type of the event, either Normal or Warning.",
+			},
+			{
+				Name:        "message",
+				Type:        proto.ColumnType_STRING,
+				Description: "Human-readable description of the event.",
+				Transform:   transform.FromField("Note"),
+			},
+			{
+				Name:        "action",
+				Type:        proto.ColumnType_STRING,
+				Description: "What action was taken or failed regarding the object.",
+			},
+			{
+				Name:        "count",
+				Type:        proto.ColumnType_INT,
+				Description: "Number of times the event has occurred.",
+				Transform:   transform.From(transformEventCount),
+			},
+			{
+				Name:        "first_timestamp",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time the event was first recorded.",
+				Transform:   transform.From(transformEventFirstTimestamp).Transform(v1TimeToRFC3339),
+			},
+			{
+				Name:        "last_timestamp",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time the event was most recently observed.",
+				Transform:   transform.From(transformEventLastTimestamp).Transform(v1TimeToRFC3339),
+			},
+			{
+				Name:        "event_time",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time the event was first observed, with microsecond precision. Not set by older reporters.",
+				Transform:   transform.FromField("EventTime").Transform(v1TimeToRFC3339),
+			},
+			{
+				Name:        "reporting_controller",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the controller that emitted the event, e.g. kubernetes.io/kubelet.",
+				Transform:   transform.From(transformEventReportingController),
+			},
+			{
+				Name:        "reporting_instance",
+				Type:        proto.ColumnType_STRING,
+				Description: "ID of the controller instance that emitted the event, e.g. kubelet-xyzf.",
+				Transform:   transform.From(transformEventReportingInstance),
+			},
+			{
+				Name:        "series",
+				Type:        proto.ColumnType_JSON,
+				Description: "Data about the series of events this event represents, if it is recurring.",
+			},
+			{
+				Name:        "related",
+				Type:        proto.ColumnType_JSON,
+				Description: "Secondary object for more complex actions, e.g. the node a pod was scheduled to.",
+			},
+
+			//// Steampipe Standard Columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: ColumnDescriptionTitle,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Type:        proto.ColumnType_JSON,
+				Description: ColumnDescriptionTags,
+				Transform:   transform.From(transformEventTags),
+			},
+		}),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sEvents(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sEvents")
+
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newEventRow, schema.GroupKind{Group: "events.k8s.io", Kind: "Event"}, schema.GroupKind{Kind: "Event"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	// Limiting the results
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < input.Limit {
+			if *limit < 1 {
+				input.Limit = 1
+			} else {
+				input.Limit = *limit
+			}
+		}
+	}
+
+	fieldSelectors := buildKubernetesEventFieldSelectorFilter(ctx, d, eventsV1FieldSelectors)
+	if len(fieldSelectors) > 0 {
+		input.FieldSelector = strings.Join(fieldSelectors, ",")
+	}
+
+	pageLeft := true
+	for pageLeft {
+		response, err := clientset.EventsV1().Events(namespace).List(ctx, input)
+		if err != nil {
+			// clusters without events.k8s.io/v1 only serve core v1 events
+			if apierrors.IsNotFound(err) && input.Continue == "" {
+				return listK8sCoreV1Events(ctx, d, namespace, input)
+			}
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sEvents, err)
+			}
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+		} else {
+			pageLeft = false
+		}
+
+		for _, event := range response.Items {
+			d.StreamListItem(ctx, Event{Event: event})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// listK8sCoreV1Events :: list events with the core v1 API, converted to their events.k8s.io/v1 form
+func listK8sCoreV1Events(ctx context.Context, d *plugin.QueryData, namespace string, input metav1.ListOptions) (interface{}, error) {
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input.FieldSelector = strings.Join(buildKubernetesEventFieldSelectorFilter(ctx, d, coreV1EventFieldSelectors), ",")
+
+	pageLeft := true
+	for pageLeft {
+		response, err := clientset.CoreV1().Events(namespace).List(ctx, input)
+		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sEvents, err)
+			}
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+		} else {
+			pageLeft = false
+		}
+
+		for _, event := range response.Items {
+			d.StreamListItem(ctx, Event{Event: coreV1EventToEventsV1(event)})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getK8sEvent(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("getK8sEvent")
+
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newEventRow, schema.GroupKind{Group: "events.k8s.io", Kind: "Event"}, schema.GroupKind{Kind: "Event"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	name := d.KeyColumnQuals["name"].GetStringValue()
+	namespace := d.KeyColumnQuals["namespace"].GetStringValue()
+
+	// return if namespace or name is empty
+	if namespace == "" || name == "" {
+		return nil, nil
+	}
+
+	event, err := clientset.EventsV1().Events(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil && apierrors.IsNotFound(err) {
+		// the event itself may be missing, or the cluster may only serve core v1 events
+		coreEvent, coreErr := clientset.CoreV1().Events(namespace).Get(ctx, name, metav1.GetOptions{})
+		if coreErr != nil {
+			if isNotFoundError(coreErr) {
+				return nil, nil
+			}
+			return nil, coreErr
+		}
+		return Event{Event: coreV1EventToEventsV1(*coreEvent)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return Event{Event: *event}, nil
+}
+
+func newEventRow(manifest manifestObject) (interface{}, error) {
+	if schema.FromAPIVersionAndKind(stringValue(manifest.Object["apiVersion"]), "").Group == "" {
+		var obj v1.Event
+		if err := manifest.decode(&obj); err != nil {
+			return nil, err
+		}
+		return Event{Event: coreV1EventToEventsV1(obj), sourceInfo: manifest.sourceInfo}, nil
+	}
+
+	var obj eventsv1.Event
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+	return Event{Event: obj, sourceInfo: manifest.sourceInfo}, nil
+}
+
+// coreV1EventToEventsV1 :: convert a core v1 event to its events.k8s.io/v1 form, as the API server does
+func coreV1EventToEventsV1(event v1.Event) eventsv1.Event {
+	converted := eventsv1.Event{
+		TypeMeta:                 metav1.TypeMeta{APIVersion: "events.k8s.io/v1", Kind: "Event"},
+		ObjectMeta:               event.ObjectMeta,
+		EventTime:                event.EventTime,
+		ReportingController:      event.ReportingController,
+		ReportingInstance:        event.ReportingInstance,
+		Action:                   event.Action,
+		Reason:                   event.Reason,
+		Regarding:                event.InvolvedObject,
+		Related:                  event.Related,
+		Note:                     event.Message,
+		Type:                     event.Type,
+		DeprecatedSource:         event.Source,
+		DeprecatedFirstTimestamp: event.FirstTimestamp,
+		DeprecatedLastTimestamp:  event.LastTimestamp,
+		DeprecatedCount:          event.Count,
+	}
+	if event.Series != nil {
+		converted.Series = &eventsv1.EventSeries{
+			Count:            event.Series.Count,
+			LastObservedTime: event.Series.LastObservedTime,
+		}
+	}
+	return converted
+}
+
+// Field selectors supported by each events API, by column
+var (
+	eventsV1FieldSelectors = map[string]string{
+		"involved_object_kind":      "regarding.kind",
+		"involved_object_name":      "regarding.name",
+		"involved_object_namespace": "regarding.namespace",
+		"involved_object_uid":       "regarding.uid",
+		"reason":                    "reason",
+		"type":                      "type",
+	}
+	coreV1EventFieldSelectors = map[string]string{
+		"involved_object_kind":      "involvedObject.kind",
+		"involved_object_name":      "involvedObject.name",
+		"involved_object_namespace": "involvedObject.namespace",
+		"involved_object_uid":       "involvedObject.uid",
+		"reason":                    "reason",
+		"type":                      "type",
+	}
+)
+
+func buildKubernetesEventFieldSelectorFilter(ctx context.Context, d *plugin.QueryData, filterQuals map[string]string) []string {
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	for columnName, filterName := range filterQuals {
+		if d.KeyColumnQualString(columnName) != "" {
+			commonFieldSelectorValue = append(commonFieldSelectorValue, filterName+"="+d.KeyColumnQualString(columnName))
+		}
+	}
+
+	return commonFieldSelectorValue
+}
+
+//// TRANSFORM FUNCTIONS
+
+// transformEventCount :: the series count of recurring events, the deprecated count of
+// events from older reporters, or 1 for a single occurrence
+func transformEventCount(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(Event)
+	if obj.Series != nil {
+		return obj.Series.Count, nil
+	}
+	if obj.DeprecatedCount > 0 {
+		return obj.DeprecatedCount, nil
+	}
+	return 1, nil
+}
+
+func transformEventFirstTimestamp(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(Event)
+	if !obj.DeprecatedFirstTimestamp.IsZero() {
+		return obj.DeprecatedFirstTimestamp, nil
+	}
+	return obj.EventTime, nil
+}
+
+func transformEventLastTimestamp(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(Event)
+	if obj.Series != nil && !obj.Series.LastObservedTime.IsZero() {
+		return obj.Series.LastObservedTime, nil
+	}
+	if !obj.DeprecatedLastTimestamp.IsZero() {
+		return obj.DeprecatedLastTimestamp, nil
+	}
+	return obj.EventTime, nil
+}
+
+// transformEventReportingController :: events from older reporters only record their source component
+func transformEventReportingController(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(Event)
+	if obj.ReportingController != "" {
+		return obj.ReportingController, nil
+	}
+	return obj.DeprecatedSource.Component, nil
+}
+
+func transformEventReportingInstance(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(Event)
+	if obj.ReportingInstance != "" {
+		return obj.ReportingInstance, nil
+	}
+	return obj.DeprecatedSource.Host, nil
+}
+
+func transformEventTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(Event)
+	return mergeTags(obj.Labels, obj.Annotations), nil
+}
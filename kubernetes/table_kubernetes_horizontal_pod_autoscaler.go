@@ -6,18 +6,26 @@ import (
 
 	"k8s.io/api/autoscaling/v2beta2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// HorizontalPodAutoscaler is a row of the kubernetes_horizontal_pod_autoscaler table
+type HorizontalPodAutoscaler struct {
+	v2beta2.HorizontalPodAutoscaler
+	sourceInfo
+}
+
 func tableKubernetesHorizontalPodAutoscaler(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_horizontal_pod_autoscaler",
-		Description: "Kubernetes HorizontalPodAutoscaler is the configuration for a horizontal pod autoscaler, which automatically manages the replica count of any resource implementing the scale subresource based on the metrics specified.",
+		Name:              "kubernetes_horizontal_pod_autoscaler",
+		Description:       "Kubernetes HorizontalPodAutoscaler is the configuration for a horizontal pod autoscaler, which automatically manages the replica count of any resource implementing the scale subresource based on the metrics specified.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sHPA,
 		},
 		List: &plugin.ListConfig{
@@ -121,6 +129,15 @@ func tableKubernetesHorizontalPodAutoscaler(ctx context.Context) *plugin.Table {
 //// HYDRATE FUNCTIONS
 
 func listK8sHPAs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newHorizontalPodAutoscalerRow, schema.GroupKind{Group: "autoscaling", Kind: "HorizontalPodAutoscaler"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		plugin.Logger(ctx).Error("listK8sHPAs", "clientset_err", err)
@@ -153,8 +170,12 @@ func listK8sHPAs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.AutoscalingV2beta2().HorizontalPodAutoscalers("").List(ctx, input)
+		response, err = clientset.AutoscalingV2beta2().HorizontalPodAutoscalers(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sHPAs, err)
+			}
 			plugin.Logger(ctx).Error("listK8sHPAs", "api_err", err)
 			return nil, err
 		}
@@ -166,7 +187,7 @@ func listK8sHPAs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 		}
 
 		for _, hpa := range response.Items {
-			d.StreamListItem(ctx, hpa)
+			d.StreamListItem(ctx, HorizontalPodAutoscaler{HorizontalPodAutoscaler: hpa})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -179,6 +200,14 @@ func listK8sHPAs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 }
 
 func getK8sHPA(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newHorizontalPodAutoscalerRow, schema.GroupKind{Group: "autoscaling", Kind: "HorizontalPodAutoscaler"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		plugin.Logger(ctx).Error("getK8sHPA", "clientset_err", err)
@@ -199,12 +228,21 @@ func getK8sHPA(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 		return nil, err
 	}
 
-	return *hpa, nil
+	return HorizontalPodAutoscaler{HorizontalPodAutoscaler: *hpa}, nil
+}
+
+func newHorizontalPodAutoscalerRow(manifest manifestObject) (interface{}, error) {
+	var obj v2beta2.HorizontalPodAutoscaler
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return HorizontalPodAutoscaler{HorizontalPodAutoscaler: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 ////// TRANSFORM FUNCTIONS
 
 func transformHpaTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v2beta2.HorizontalPodAutoscaler)
+	obj := d.HydrateItem.(HorizontalPodAutoscaler)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
@@ -6,18 +6,26 @@ import (
 
 	"k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// Ingress is a row of the kubernetes_ingress table
+type Ingress struct {
+	v1beta1.Ingress
+	sourceInfo
+}
+
 func tableKubernetesIngress(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_ingress",
-		Description: "Ingress exposes HTTP and HTTPS routes from outside the cluster to services within the cluster. Traffic routing is controlled by rules defined on the Ingress resource.",
+		Name:              "kubernetes_ingress",
+		Description:       "Ingress exposes HTTP and HTTPS routes from outside the cluster to services within the cluster. Traffic routing is controlled by rules defined on the Ingress resource.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sIngress,
 		},
 		List: &plugin.ListConfig{
@@ -82,6 +90,15 @@ func listK8sIngresses(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrat
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sIngresses")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newIngressRow, schema.GroupKind{Group: "networking.k8s.io", Kind: "Ingress"}, schema.GroupKind{Group: "extensions", Kind: "Ingress"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -113,8 +130,12 @@ func listK8sIngresses(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrat
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.ExtensionsV1beta1().Ingresses("").List(ctx, input)
+		response, err = clientset.ExtensionsV1beta1().Ingresses(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sIngresses, err)
+			}
 			return nil, err
 		}
 
@@ -125,7 +146,7 @@ func listK8sIngresses(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrat
 		}
 
 		for _, ingress := range response.Items {
-			d.StreamListItem(ctx, ingress)
+			d.StreamListItem(ctx, Ingress{Ingress: ingress})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -141,6 +162,14 @@ func getK8sIngress(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDa
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sIngress")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newIngressRow, schema.GroupKind{Group: "networking.k8s.io", Kind: "Ingress"}, schema.GroupKind{Group: "extensions", Kind: "Ingress"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -159,12 +188,21 @@ func getK8sIngress(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDa
 		return nil, err
 	}
 
-	return *ingress, nil
+	return Ingress{Ingress: *ingress}, nil
+}
+
+func newIngressRow(manifest manifestObject) (interface{}, error) {
+	var obj v1beta1.Ingress
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return Ingress{Ingress: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformIngressTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1beta1.Ingress)
+	obj := d.HydrateItem.(Ingress)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
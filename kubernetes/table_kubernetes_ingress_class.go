@@ -0,0 +1,192 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// ingressClassIsDefaultAnnotation marks the default ingress class
+const ingressClassIsDefaultAnnotation = "ingressclass.kubernetes.io/is-default-class"
+
+// IngressClass is a row of the kubernetes_ingress_class table
+type IngressClass struct {
+	v1.IngressClass
+	sourceInfo
+}
+
+func tableKubernetesIngressClass(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_ingress_class",
+		Description:       "An IngressClass names the ingress controller which implements ingresses of the class, and the parameters passed to it.",
+		GetMatrixItemFunc: BuildContextList,
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
+			Hydrate:    getK8sIngressClass,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listK8sIngressClasses,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
+		},
+		Columns: k8sCommonGlobalColumns([]*plugin.Column{
+			{
+				Name:        "controller",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the controller which implements ingresses of the class, e.g. k8s.io/ingress-nginx.",
+				Transform:   transform.FromField("Spec.Controller"),
+			},
+			{
+				Name:        "parameters",
+				Type:        proto.ColumnType_JSON,
+				Description: "Reference to a resource holding additional configuration for the controller.",
+				Transform:   transform.FromField("Spec.Parameters"),
+			},
+			{
+				Name:        "is_default",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the class is annotated as the default ingress class, assigned to ingresses which do not specify a class.",
+				Transform:   transform.From(transformIngressClassIsDefault),
+			},
+
+			//// Steampipe Standard Columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: ColumnDescriptionTitle,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Type:        proto.ColumnType_JSON,
+				Description: ColumnDescriptionTags,
+				Transform:   transform.From(transformIngressClassTags),
+			},
+		}),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sIngressClasses(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sIngressClasses")
+
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newIngressClassRow, schema.GroupKind{Group: "networking.k8s.io", Kind: "IngressClass"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	// Limiting the results
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < input.Limit {
+			if *limit < 1 {
+				input.Limit = 1
+			} else {
+				input.Limit = *limit
+			}
+		}
+	}
+
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
+	var response *v1.IngressClassList
+	pageLeft := true
+
+	for pageLeft {
+		response, err = clientset.NetworkingV1().IngressClasses().List(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+		} else {
+			pageLeft = false
+		}
+
+		for _, ingressClass := range response.Items {
+			d.StreamListItem(ctx, IngressClass{IngressClass: ingressClass})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getK8sIngressClass(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("getK8sIngressClass")
+
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newIngressClassRow, schema.GroupKind{Group: "networking.k8s.io", Kind: "IngressClass"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	name := d.KeyColumnQuals["name"].GetStringValue()
+
+	// return if name is empty
+	if name == "" {
+		return nil, nil
+	}
+
+	ingressClass, err := clientset.NetworkingV1().IngressClasses().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return IngressClass{IngressClass: *ingressClass}, nil
+}
+
+func newIngressClassRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.IngressClass
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return IngressClass{IngressClass: obj, sourceInfo: manifest.sourceInfo}, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func transformIngressClassIsDefault(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(IngressClass)
+	return obj.Annotations[ingressClassIsDefaultAnnotation] == "true", nil
+}
+
+func transformIngressClassTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(IngressClass)
+	return mergeTags(obj.Labels, obj.Annotations), nil
+}
@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// Job is a row of the kubernetes_job table
+type Job struct {
+	v1.Job
+	sourceInfo
+}
+
 func tableKubernetesJob(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_job",
-		Description: "A Job creates one or more Pods and will continue to retry execution of the Pods until a specified number of them successfully terminate.",
+		Name:              "kubernetes_job",
+		Description:       "A Job creates one or more Pods and will continue to retry execution of the Pods until a specified number of them successfully terminate.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sJob,
 		},
 		List: &plugin.ListConfig{
@@ -142,6 +150,15 @@ func listK8sJobs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sJobs")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newJobRow, schema.GroupKind{Group: "batch", Kind: "Job"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -173,8 +190,12 @@ func listK8sJobs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.BatchV1().Jobs("").List(ctx, input)
+		response, err = clientset.BatchV1().Jobs(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sJobs, err)
+			}
 			return nil, err
 		}
 
@@ -185,7 +206,7 @@ func listK8sJobs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 		}
 
 		for _, job := range response.Items {
-			d.StreamListItem(ctx, job)
+			d.StreamListItem(ctx, Job{Job: job})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -201,6 +222,14 @@ func getK8sJob(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sJob")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newJobRow, schema.GroupKind{Group: "batch", Kind: "Job"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -219,12 +248,21 @@ func getK8sJob(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 		return nil, err
 	}
 
-	return *job, nil
+	return Job{Job: *job}, nil
+}
+
+func newJobRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.Job
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return Job{Job: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformJobTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.Job)
+	obj := d.HydrateItem.(Job)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
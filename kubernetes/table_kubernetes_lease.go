@@ -0,0 +1,238 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// Lease is a row of the kubernetes_lease table
+type Lease struct {
+	v1.Lease
+	sourceInfo
+}
+
+func tableKubernetesLease(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_lease",
+		Description:       "A Lease is a lightweight lock in the coordination.k8s.io API, used for controller leader election and for node heartbeats in the kube-node-lease namespace.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
+			Hydrate:    getK8sLease,
+		},
+		List: &plugin.ListConfig{
+			Hydrate:    listK8sLeases,
+			KeyColumns: getCommonOptionalKeyQuals(),
+		},
+		Columns: k8sCommonColumns([]*plugin.Column{
+			{
+				Name:        "holder_identity",
+				Type:        proto.ColumnType_STRING,
+				Description: "Identity of the current holder of the lease, e.g. the pod of the elected leader or the name of a node.",
+				Transform:   transform.FromField("Spec.HolderIdentity"),
+			},
+			{
+				Name:        "lease_duration_seconds",
+				Type:        proto.ColumnType_INT,
+				Description: "Number of seconds candidates wait after the last renewal before they may take the lease.",
+				Transform:   transform.FromField("Spec.LeaseDurationSeconds"),
+			},
+			{
+				Name:        "acquire_time",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time the current holder acquired the lease.",
+				Transform:   transform.FromField("Spec.AcquireTime").Transform(v1TimeToRFC3339),
+			},
+			{
+				Name:        "renew_time",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time the current holder last renewed the lease.",
+				Transform:   transform.FromField("Spec.RenewTime").Transform(v1TimeToRFC3339),
+			},
+			{
+				Name:        "lease_transitions",
+				Type:        proto.ColumnType_INT,
+				Description: "Number of times the lease has changed holder.",
+				Transform:   transform.FromField("Spec.LeaseTransitions"),
+			},
+			{
+				Name:        "seconds_since_renew",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "Number of seconds since the lease was last renewed, at the time of the query.",
+				Transform:   transform.From(transformLeaseSecondsSinceRenew),
+			},
+			{
+				Name:        "expired",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the lease was last renewed more than its duration ago, so its holder has stopped renewing it.",
+				Transform:   transform.From(transformLeaseExpired),
+			},
+
+			//// Steampipe Standard Columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: ColumnDescriptionTitle,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Type:        proto.ColumnType_JSON,
+				Description: ColumnDescriptionTags,
+				Transform:   transform.From(transformLeaseTags),
+			},
+		}),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sLeases(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sLeases")
+
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newLeaseRow, schema.GroupKind{Group: "coordination.k8s.io", Kind: "Lease"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	// Limiting the results
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < input.Limit {
+			if *limit < 1 {
+				input.Limit = 1
+			} else {
+				input.Limit = *limit
+			}
+		}
+	}
+
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
+	var response *v1.LeaseList
+	pageLeft := true
+
+	for pageLeft {
+		response, err = clientset.CoordinationV1().Leases(namespace).List(ctx, input)
+		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sLeases, err)
+			}
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+		} else {
+			pageLeft = false
+		}
+
+		for _, lease := range response.Items {
+			d.StreamListItem(ctx, Lease{Lease: lease})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getK8sLease(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("getK8sLease")
+
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newLeaseRow, schema.GroupKind{Group: "coordination.k8s.io", Kind: "Lease"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	name := d.KeyColumnQuals["name"].GetStringValue()
+	namespace := d.KeyColumnQuals["namespace"].GetStringValue()
+
+	// return if namespace or name is empty
+	if namespace == "" || name == "" {
+		return nil, nil
+	}
+
+	lease, err := clientset.CoordinationV1().Leases(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return Lease{Lease: *lease}, nil
+}
+
+func newLeaseRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.Lease
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return Lease{Lease: obj, sourceInfo: manifest.sourceInfo}, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func transformLeaseSecondsSinceRenew(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	renewTime := d.HydrateItem.(Lease).Spec.RenewTime
+	if renewTime == nil || renewTime.IsZero() {
+		return nil, nil
+	}
+	return time.Since(renewTime.Time).Seconds(), nil
+}
+
+func transformLeaseExpired(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	spec := d.HydrateItem.(Lease).Spec
+	if spec.RenewTime == nil || spec.RenewTime.IsZero() || spec.LeaseDurationSeconds == nil {
+		return nil, nil
+	}
+	expiry := spec.RenewTime.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second)
+	return time.Now().After(expiry), nil
+}
+
+func transformLeaseTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(Lease)
+	return mergeTags(obj.Labels, obj.Annotations), nil
+}
@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// LimitRange is a row of the kubernetes_limit_range table
+type LimitRange struct {
+	v1.LimitRange
+	sourceInfo
+}
+
 func tableKubernetesLimitRange(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_limit_range",
-		Description: "Kubernetes Limit Range",
+		Name:              "kubernetes_limit_range",
+		Description:       "Kubernetes Limit Range",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sLimitRange,
 		},
 		List: &plugin.ListConfig{
@@ -56,6 +64,15 @@ func tableKubernetesLimitRange(ctx context.Context) *plugin.Table {
 func listK8sLimitRanges(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("listK8sLimitRanges")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newLimitRangeRow, schema.GroupKind{Kind: "LimitRange"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -87,8 +104,12 @@ func listK8sLimitRanges(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.CoreV1().LimitRanges("").List(ctx, input)
+		response, err = clientset.CoreV1().LimitRanges(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sLimitRanges, err)
+			}
 			return nil, err
 		}
 
@@ -99,7 +120,7 @@ func listK8sLimitRanges(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 		}
 
 		for _, limitRange := range response.Items {
-			d.StreamListItem(ctx, limitRange)
+			d.StreamListItem(ctx, LimitRange{LimitRange: limitRange})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -114,6 +135,14 @@ func listK8sLimitRanges(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 func getK8sLimitRange(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("getK8sLimitRange")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newLimitRangeRow, schema.GroupKind{Kind: "LimitRange"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -132,12 +161,21 @@ func getK8sLimitRange(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrat
 		return nil, err
 	}
 
-	return *limitRange, nil
+	return LimitRange{LimitRange: *limitRange}, nil
+}
+
+func newLimitRangeRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.LimitRange
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return LimitRange{LimitRange: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformLimitRangeTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.LimitRange)
+	obj := d.HydrateItem.(LimitRange)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
@@ -0,0 +1,149 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// MutatingWebhook is a row of the kubernetes_mutating_webhook_configuration table
+type MutatingWebhook struct {
+	admissionregistrationv1.MutatingWebhook
+	admissionWebhookInfo
+	sourceInfo
+}
+
+func (w MutatingWebhook) webhookClientConfig() admissionregistrationv1.WebhookClientConfig {
+	return w.ClientConfig
+}
+
+func (w MutatingWebhook) webhookFailurePolicy() *admissionregistrationv1.FailurePolicyType {
+	return w.FailurePolicy
+}
+
+func (w MutatingWebhook) webhookNamespaceSelector() *metav1.LabelSelector {
+	return w.NamespaceSelector
+}
+
+func (w MutatingWebhook) webhookInfo() admissionWebhookInfo {
+	return w.admissionWebhookInfo
+}
+
+func tableKubernetesMutatingWebhookConfiguration(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_mutating_webhook_configuration",
+		Description:       "The webhooks of every MutatingWebhookConfiguration, with one row per webhook. Mutating webhooks are called by the API server to change objects before they are validated and stored.",
+		GetMatrixItemFunc: BuildContextList,
+		List: &plugin.ListConfig{
+			Hydrate: listK8sMutatingWebhooks,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "configuration_name", Require: plugin.Optional},
+			},
+		},
+		Columns: admissionWebhookColumns(
+			&plugin.Column{
+				Name:        "reinvocation_policy",
+				Type:        proto.ColumnType_STRING,
+				Description: "Whether the webhook is called again if a later mutating webhook changes the object: Never (the default) or IfNeeded.",
+				Transform:   transform.FromField("ReinvocationPolicy"),
+			},
+		),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sMutatingWebhooks(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sMutatingWebhooks")
+
+	kubeSystemLabels, err := getKubeSystemNamespaceLabels(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	configurationName := d.KeyColumnQualString("configuration_name")
+
+	if isManifestSource(d) {
+		manifests, err := listManifestObjects(ctx, d, schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"})
+		if err != nil {
+			return nil, err
+		}
+		for _, manifest := range manifests {
+			var configuration admissionregistrationv1.MutatingWebhookConfiguration
+			if err := manifest.decode(&configuration); err != nil {
+				return nil, fmt.Errorf("%s: %v", manifest.Path, err)
+			}
+			if configurationName != "" && configuration.Name != configurationName {
+				continue
+			}
+			if done := streamMutatingWebhooks(ctx, d, configuration, kubeSystemLabels, manifest.sourceInfo); done {
+				return nil, nil
+			}
+		}
+		return nil, nil
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	if configurationName != "" {
+		input.FieldSelector = fmt.Sprintf("metadata.name=%v", configurationName)
+	}
+
+	var response *admissionregistrationv1.MutatingWebhookConfigurationList
+	pageLeft := true
+
+	for pageLeft {
+		response, err = clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+		} else {
+			pageLeft = false
+		}
+
+		for _, configuration := range response.Items {
+			if done := streamMutatingWebhooks(ctx, d, configuration, kubeSystemLabels, sourceInfo{}); done {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// streamMutatingWebhooks :: stream a row per webhook of the configuration. done is true when
+// the query needs no more rows, e.g. because its limit has been hit.
+func streamMutatingWebhooks(ctx context.Context, d *plugin.QueryData, configuration admissionregistrationv1.MutatingWebhookConfiguration, kubeSystemLabels labels.Set, source sourceInfo) (done bool) {
+	for _, webhook := range configuration.Webhooks {
+		d.StreamListItem(ctx, MutatingWebhook{
+			MutatingWebhook:      webhook,
+			admissionWebhookInfo: newAdmissionWebhookInfo(configuration.ObjectMeta, kubeSystemLabels),
+			sourceInfo:           source,
+		})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			return true
+		}
+	}
+	return false
+}
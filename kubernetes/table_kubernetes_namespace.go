@@ -3,27 +3,37 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// Namespace is a row of the kubernetes_namespace table
+type Namespace struct {
+	v1.Namespace
+	sourceInfo
+}
+
 func tableKubernetesNamespace(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_namespace",
-		Description: "Kubernetes Namespace provides a scope for Names.",
+		Name:              "kubernetes_namespace",
+		Description:       "Kubernetes Namespace provides a scope for Names.",
+		GetMatrixItemFunc: BuildContextList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.SingleColumn("name"),
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
 			Hydrate:    getK8sNamespace,
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listK8sNamespaces,
 			KeyColumns: []*plugin.KeyColumn{
 				{Name: "phase", Require: plugin.Optional},
+				{Name: "name", Require: plugin.Optional},
 			},
 		},
 		Columns: k8sCommonGlobalColumns([]*plugin.Column{
@@ -73,6 +83,10 @@ func listK8sNamespaces(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sNamespaces")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newNamespaceRow, schema.GroupKind{Kind: "Namespace"})
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -94,8 +108,14 @@ func listK8sNamespaces(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 		}
 	}
 
+	fieldSelectors := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
 	if d.KeyColumnQualString("phase") != "" {
-		input.FieldSelector = fmt.Sprintf("status.phase=%v", d.KeyColumnQualString("phase"))
+		fieldSelectors = append(fieldSelectors, fmt.Sprintf("status.phase=%v", d.KeyColumnQualString("phase")))
+	}
+
+	if len(fieldSelectors) > 0 {
+		input.FieldSelector = strings.Join(fieldSelectors, ",")
 	}
 
 	var response *v1.NamespaceList
@@ -114,7 +134,7 @@ func listK8sNamespaces(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 		}
 
 		for _, pod := range response.Items {
-			d.StreamListItem(ctx, pod)
+			d.StreamListItem(ctx, Namespace{Namespace: pod})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -130,6 +150,10 @@ func getK8sNamespace(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sNamespace")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newNamespaceRow, schema.GroupKind{Kind: "Namespace"})
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -147,12 +171,21 @@ func getK8sNamespace(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		return nil, err
 	}
 
-	return *namespace, nil
+	return Namespace{Namespace: *namespace}, nil
+}
+
+func newNamespaceRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.Namespace
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return Namespace{Namespace: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformNamespaceTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.Namespace)
+	obj := d.HydrateItem.(Namespace)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// NetworkPolicy is a row of the kubernetes_network_policy table
+type NetworkPolicy struct {
+	v1.NetworkPolicy
+	sourceInfo
+}
+
 func tableKubernetesNetworkPolicy(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_network_policy",
-		Description: "Network policy specifiy how pods are allowed to communicate with each other and with other network endpoints.",
+		Name:              "kubernetes_network_policy",
+		Description:       "Network policy specifiy how pods are allowed to communicate with each other and with other network endpoints.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sNetworkPolicy,
 		},
 		List: &plugin.ListConfig{
@@ -74,6 +82,15 @@ func listK8sNetworkPolicies(ctx context.Context, d *plugin.QueryData, _ *plugin.
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sNetworkPolicies")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newNetworkPolicyRow, schema.GroupKind{Group: "networking.k8s.io", Kind: "NetworkPolicy"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -105,8 +122,12 @@ func listK8sNetworkPolicies(ctx context.Context, d *plugin.QueryData, _ *plugin.
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.NetworkingV1().NetworkPolicies("").List(ctx, input)
+		response, err = clientset.NetworkingV1().NetworkPolicies(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sNetworkPolicies, err)
+			}
 			return nil, err
 		}
 
@@ -117,7 +138,7 @@ func listK8sNetworkPolicies(ctx context.Context, d *plugin.QueryData, _ *plugin.
 		}
 
 		for _, networkPolicy := range response.Items {
-			d.StreamListItem(ctx, networkPolicy)
+			d.StreamListItem(ctx, NetworkPolicy{NetworkPolicy: networkPolicy})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -133,6 +154,14 @@ func getK8sNetworkPolicy(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sNetworkPolicy")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newNetworkPolicyRow, schema.GroupKind{Group: "networking.k8s.io", Kind: "NetworkPolicy"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -151,12 +180,21 @@ func getK8sNetworkPolicy(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 		return nil, err
 	}
 
-	return *networkPolicy, nil
+	return NetworkPolicy{NetworkPolicy: *networkPolicy}, nil
+}
+
+func newNetworkPolicyRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.NetworkPolicy
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return NetworkPolicy{NetworkPolicy: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformNetworkPolicyTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.NetworkPolicy)
+	obj := d.HydrateItem.(NetworkPolicy)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
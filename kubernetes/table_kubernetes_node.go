@@ -2,25 +2,37 @@ package kubernetes
 
 import (
 	"context"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// Node is a row of the kubernetes_node table
+type Node struct {
+	v1.Node
+	sourceInfo
+}
+
 func tableKubernetesNode(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_node",
-		Description: "Kubernetes Node is a worker node in Kubernetes.",
+		Name:              "kubernetes_node",
+		Description:       "Kubernetes Node is a worker node in Kubernetes.",
+		GetMatrixItemFunc: BuildContextList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.SingleColumn("name"),
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
 			Hydrate:    getK8sNode,
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listK8sNodes,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
 		},
 		Columns: k8sCommonGlobalColumns([]*plugin.Column{
 			//// NodeSpec
@@ -153,6 +165,10 @@ func listK8sNodes(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sNodes")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newNodeRow, schema.GroupKind{Kind: "Node"})
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -174,6 +190,12 @@ func listK8sNodes(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 		}
 	}
 
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
 	var response *v1.NodeList
 	pageLeft := true
 
@@ -190,7 +212,7 @@ func listK8sNodes(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 		}
 
 		for _, pod := range response.Items {
-			d.StreamListItem(ctx, pod)
+			d.StreamListItem(ctx, Node{Node: pod})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -206,6 +228,10 @@ func getK8sNode(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sNode")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newNodeRow, schema.GroupKind{Kind: "Node"})
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -223,12 +249,21 @@ func getK8sNode(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 		return nil, err
 	}
 
-	return *node, nil
+	return Node{Node: *node}, nil
+}
+
+func newNodeRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.Node
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return Node{Node: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformNodeTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.Node)
+	obj := d.HydrateItem.(Node)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
@@ -0,0 +1,161 @@
+package kubernetes
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// NodeMetric is a row of the kubernetes_node_metric table
+type NodeMetric struct {
+	metricsv1beta1.NodeMetrics
+}
+
+func tableKubernetesNodeMetric(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_node_metric",
+		Description:       "CPU and memory usage of each node, from the metrics.k8s.io API served by metrics-server. No rows are returned if the API is not installed.",
+		GetMatrixItemFunc: BuildContextList,
+		List: &plugin.ListConfig{
+			Hydrate: listK8sNodeMetrics,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
+		},
+		Columns: append([]*plugin.Column{
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the node.",
+			},
+			{
+				Name:        "cpu_usage_millicores",
+				Type:        proto.ColumnType_INT,
+				Description: "CPU used by the node over the sample window, in millicores.",
+				Transform:   transform.FromField("Usage").TransformP(transformMetricsUsage, v1.ResourceCPU),
+			},
+			{
+				Name:        "memory_usage_bytes",
+				Type:        proto.ColumnType_INT,
+				Description: "Working set memory of the node at the end of the sample window, in bytes.",
+				Transform:   transform.FromField("Usage").TransformP(transformMetricsUsage, v1.ResourceMemory),
+			},
+			{
+				Name:        "window_seconds",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "Length of the window the usage was sampled over, in seconds.",
+				Transform:   transform.FromField("Window").Transform(transformMetricsWindow),
+			},
+			{
+				Name:        "timestamp",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time at the end of the sample window.",
+				Transform:   transform.FromField("Timestamp").Transform(v1TimeToRFC3339),
+			},
+			{
+				Name:        "labels",
+				Type:        proto.ColumnType_JSON,
+				Description: "Labels of the node.",
+			},
+		}, kubectlConfigColumns()...),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sNodeMetrics(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sNodeMetrics")
+
+	// manifest sources have no metrics
+	if isManifestSource(d) {
+		return nil, nil
+	}
+
+	clientset, err := GetNewClientMetrics(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := d.KeyColumnQualString("name"); name != "" {
+		metrics, err := clientset.MetricsV1beta1().NodeMetricses().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			// also covers a node without metrics
+			if isMetricsAPIUnavailable(ctx, err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		d.StreamListItem(ctx, NodeMetric{*metrics})
+		return nil, nil
+	}
+
+	// metrics-server answers from memory and does not page
+	response, err := clientset.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if isMetricsAPIUnavailable(ctx, err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, metrics := range response.Items {
+		d.StreamListItem(ctx, NodeMetric{metrics})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// isMetricsAPIUnavailable :: whether a list failed because the metrics.k8s.io API is not
+// installed, or is registered but its backing service (usually metrics-server) is down
+func isMetricsAPIUnavailable(ctx context.Context, err error) bool {
+	if apierrors.IsNotFound(err) {
+		return true
+	}
+	if apierrors.IsServiceUnavailable(err) {
+		plugin.Logger(ctx).Warn("isMetricsAPIUnavailable", "metrics_api_error", err)
+		return true
+	}
+	return false
+}
+
+//// TRANSFORM FUNCTIONS
+
+// transformMetricsUsage :: CPU usage in millicores, or other resources in their base unit
+func transformMetricsUsage(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	usage, ok := d.Value.(v1.ResourceList)
+	if !ok {
+		return nil, nil
+	}
+
+	name := d.Param.(v1.ResourceName)
+	quantity, ok := usage[name]
+	if !ok {
+		return nil, nil
+	}
+
+	if name == v1.ResourceCPU {
+		return quantity.MilliValue(), nil
+	}
+	return quantity.Value(), nil
+}
+
+func transformMetricsWindow(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	window, ok := d.Value.(metav1.Duration)
+	if !ok {
+		return nil, nil
+	}
+	return window.Seconds(), nil
+}
@@ -2,25 +2,37 @@ package kubernetes
 
 import (
 	"context"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// PersistentVolume is a row of the kubernetes_persistent_volume table
+type PersistentVolume struct {
+	v1.PersistentVolume
+	sourceInfo
+}
+
 func tableKubernetesPersistentVolume(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_persistent_volume",
-		Description: "A PersistentVolume (PV) is a piece of storage in the cluster that has been provisioned by an administrator or dynamically provisioned using Storage Classes. PVs are volume plugins like Volumes, but have a lifecycle independent of any individual Pod that uses the PV.",
+		Name:              "kubernetes_persistent_volume",
+		Description:       "A PersistentVolume (PV) is a piece of storage in the cluster that has been provisioned by an administrator or dynamically provisioned using Storage Classes. PVs are volume plugins like Volumes, but have a lifecycle independent of any individual Pod that uses the PV.",
+		GetMatrixItemFunc: BuildContextList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.SingleColumn("name"),
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
 			Hydrate:    getK8sPV,
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listK8sPVs,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
 		},
 		Columns: k8sCommonGlobalColumns([]*plugin.Column{
 			//// PersistentVolumeSpec columns
@@ -122,6 +134,10 @@ func listK8sPVs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sPVs")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newPersistentVolumeRow, schema.GroupKind{Kind: "PersistentVolume"})
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -143,6 +159,12 @@ func listK8sPVs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 		}
 	}
 
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
 	var response *v1.PersistentVolumeList
 	pageLeft := true
 
@@ -159,7 +181,7 @@ func listK8sPVs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 		}
 
 		for _, persistentVolume := range response.Items {
-			d.StreamListItem(ctx, persistentVolume)
+			d.StreamListItem(ctx, PersistentVolume{PersistentVolume: persistentVolume})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -175,6 +197,10 @@ func getK8sPV(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sPV")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newPersistentVolumeRow, schema.GroupKind{Kind: "PersistentVolume"})
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -192,12 +218,21 @@ func getK8sPV(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (
 		return nil, err
 	}
 
-	return *persistentVolume, nil
+	return PersistentVolume{PersistentVolume: *persistentVolume}, nil
+}
+
+func newPersistentVolumeRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.PersistentVolume
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return PersistentVolume{PersistentVolume: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformPVTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.PersistentVolume)
+	obj := d.HydrateItem.(PersistentVolume)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// PersistentVolumeClaim is a row of the kubernetes_persistent_volume_claim table
+type PersistentVolumeClaim struct {
+	v1.PersistentVolumeClaim
+	sourceInfo
+}
+
 func tableKubernetesPersistentVolumeClaim(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_persistent_volume_claim",
-		Description: "A PersistentVolumeClaim (PVC) is a request for storage by a user.",
+		Name:              "kubernetes_persistent_volume_claim",
+		Description:       "A PersistentVolumeClaim (PVC) is a request for storage by a user.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sPVC,
 		},
 		List: &plugin.ListConfig{
@@ -118,6 +126,15 @@ func listK8sPVCs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sPVCs")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newPersistentVolumeClaimRow, schema.GroupKind{Kind: "PersistentVolumeClaim"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -149,8 +166,12 @@ func listK8sPVCs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.CoreV1().PersistentVolumeClaims("").List(ctx, input)
+		response, err = clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sPVCs, err)
+			}
 			return nil, err
 		}
 
@@ -161,7 +182,7 @@ func listK8sPVCs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 		}
 
 		for _, persistentVolumeClaim := range response.Items {
-			d.StreamListItem(ctx, persistentVolumeClaim)
+			d.StreamListItem(ctx, PersistentVolumeClaim{PersistentVolumeClaim: persistentVolumeClaim})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -177,6 +198,14 @@ func getK8sPVC(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sPVC")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newPersistentVolumeClaimRow, schema.GroupKind{Kind: "PersistentVolumeClaim"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -195,12 +224,21 @@ func getK8sPVC(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 		return nil, err
 	}
 
-	return *persistentVolumeClaim, nil
+	return PersistentVolumeClaim{PersistentVolumeClaim: *persistentVolumeClaim}, nil
+}
+
+func newPersistentVolumeClaimRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.PersistentVolumeClaim
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return PersistentVolumeClaim{PersistentVolumeClaim: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformPVCTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.PersistentVolumeClaim)
+	obj := d.HydrateItem.(PersistentVolumeClaim)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// Pod is a row of the kubernetes_pod table
+type Pod struct {
+	v1.Pod
+	sourceInfo
+}
+
 func tableKubernetesPod(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_pod",
-		Description: "Kubernetes Pod is a collection of containers that can run on a host. This resource is created by clients and scheduled onto hosts.",
+		Name:              "kubernetes_pod",
+		Description:       "Kubernetes Pod is a collection of containers that can run on a host. This resource is created by clients and scheduled onto hosts.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sPod,
 		},
 		List: &plugin.ListConfig{
@@ -424,6 +432,15 @@ func listK8sPods(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sPods")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newPodRow, schema.GroupKind{Kind: "Pod"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -461,8 +478,12 @@ func listK8sPods(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.CoreV1().Pods("").List(ctx, input)
+		response, err = clientset.CoreV1().Pods(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sPods, err)
+			}
 			return nil, err
 		}
 
@@ -473,7 +494,7 @@ func listK8sPods(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 		}
 
 		for _, pod := range response.Items {
-			d.StreamListItem(ctx, pod)
+			d.StreamListItem(ctx, Pod{Pod: pod})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -489,6 +510,14 @@ func getK8sPod(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sPod")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newPodRow, schema.GroupKind{Kind: "Pod"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -507,13 +536,22 @@ func getK8sPod(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 		return nil, err
 	}
 
-	return *pod, nil
+	return Pod{Pod: *pod}, nil
+}
+
+func newPodRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.Pod
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return Pod{Pod: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformPodTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.Pod)
+	obj := d.HydrateItem.(Pod)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
 
@@ -7,18 +7,26 @@ import (
 	v1beta1 "k8s.io/api/policy/v1beta1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// PodDisruptionBudget is a row of the kubernetes_pod_disruption_budget table
+type PodDisruptionBudget struct {
+	v1beta1.PodDisruptionBudget
+	sourceInfo
+}
+
 func tableKubernetesPDB(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_pod_disruption_budget",
-		Description: "A Pod Disruption Budget limits the number of Pods of a replicated application that are down simultaneously from voluntary disruptions.",
+		Name:              "kubernetes_pod_disruption_budget",
+		Description:       "A Pod Disruption Budget limits the number of Pods of a replicated application that are down simultaneously from voluntary disruptions.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getPDB,
 		},
 		List: &plugin.ListConfig{
@@ -70,6 +78,15 @@ func listPDBs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (
 	logger := plugin.Logger(ctx)
 	logger.Trace("listPDBs")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newPodDisruptionBudgetRow, schema.GroupKind{Group: "policy", Kind: "PodDisruptionBudget"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -101,8 +118,12 @@ func listPDBs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.PolicyV1beta1().PodDisruptionBudgets("").List(ctx, input)
+		response, err = clientset.PolicyV1beta1().PodDisruptionBudgets(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listPDBs, err)
+			}
 			return nil, err
 		}
 
@@ -113,7 +134,7 @@ func listPDBs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (
 		}
 
 		for _, item := range response.Items {
-			d.StreamListItem(ctx, item)
+			d.StreamListItem(ctx, PodDisruptionBudget{PodDisruptionBudget: item})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -129,6 +150,14 @@ func getPDB(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (in
 	logger := plugin.Logger(ctx)
 	logger.Trace("getPDB")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newPodDisruptionBudgetRow, schema.GroupKind{Group: "policy", Kind: "PodDisruptionBudget"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -147,12 +176,21 @@ func getPDB(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (in
 		return nil, err
 	}
 
-	return *pdb, nil
+	return PodDisruptionBudget{PodDisruptionBudget: *pdb}, nil
+}
+
+func newPodDisruptionBudgetRow(manifest manifestObject) (interface{}, error) {
+	var obj v1beta1.PodDisruptionBudget
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return PodDisruptionBudget{PodDisruptionBudget: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformPDBTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1beta1.PodDisruptionBudget)
+	obj := d.HydrateItem.(PodDisruptionBudget)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
@@ -0,0 +1,265 @@
+package kubernetes
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// PodLogLine is a row of the kubernetes_pod_log table
+type PodLogLine struct {
+	Namespace     string
+	PodName       string
+	ContainerName string
+	LineNumber    int
+	Timestamp     *time.Time
+	Log           string
+}
+
+func tableKubernetesPodLog(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_pod_log",
+		Description:       "Log lines of the containers of a pod, read from the pods/log subresource. Queries must specify the namespace and pod name.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
+		List: &plugin.ListConfig{
+			Hydrate: listK8sPodLogs,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "namespace", Require: plugin.Required},
+				{Name: "pod_name", Require: plugin.Required},
+				{Name: "container_name", Require: plugin.Optional},
+				{Name: "since_seconds", Require: plugin.Optional},
+				{Name: "since_time", Require: plugin.Optional},
+				{Name: "tail_lines", Require: plugin.Optional},
+				{Name: "previous", Require: plugin.Optional, Operators: []string{"=", "<>"}},
+				{Name: "timestamps", Require: plugin.Optional, Operators: []string{"=", "<>"}},
+			},
+		},
+		Columns: append([]*plugin.Column{
+			{
+				Name:        "namespace",
+				Type:        proto.ColumnType_STRING,
+				Description: "Namespace of the pod.",
+			},
+			{
+				Name:        "pod_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the pod.",
+			},
+			{
+				Name:        "container_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the container. If not specified, the logs of every init, regular and ephemeral container of the pod are read.",
+			},
+			{
+				Name:        "line_number",
+				Type:        proto.ColumnType_INT,
+				Description: "Number of the line in the logs read for the container, starting at 1.",
+			},
+			{
+				Name:        "timestamp",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time the line was logged, as recorded by the container runtime.",
+			},
+			{
+				Name:        "log",
+				Type:        proto.ColumnType_STRING,
+				Description: "The log line. It is prefixed with its timestamp if timestamps is true.",
+			},
+			{
+				Name:        "since_seconds",
+				Type:        proto.ColumnType_INT,
+				Description: "Only read lines newer than this many seconds.",
+				Transform:   transform.FromQual("since_seconds"),
+			},
+			{
+				Name:        "since_time",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Only read lines logged after this time.",
+				Transform:   transform.FromQual("since_time"),
+			},
+			{
+				Name:        "tail_lines",
+				Type:        proto.ColumnType_INT,
+				Description: "Only read this many lines from the end of the logs.",
+				Transform:   transform.FromQual("tail_lines"),
+			},
+			{
+				Name:        "previous",
+				Type:        proto.ColumnType_BOOL,
+				Description: "If true, read the logs of the previous, terminated instance of the container.",
+				Transform:   transform.FromQual("previous"),
+			},
+			{
+				Name:        "timestamps",
+				Type:        proto.ColumnType_BOOL,
+				Description: "If true, keep the timestamp prefix of each line in the log column.",
+				Transform:   transform.FromQual("timestamps"),
+			},
+		}, kubectlConfigColumns()...),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sPodLogs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sPodLogs")
+
+	// manifest sources have no logs
+	if isManifestSource(d) {
+		return nil, nil
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok || namespace == "" {
+		return nil, err
+	}
+
+	podName := d.KeyColumnQualString("pod_name")
+	if podName == "" {
+		return nil, nil
+	}
+
+	clientset, err := getNewClientsetForLogs(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// timestamps are always requested, so the timestamp column can be set
+	options := v1.PodLogOptions{
+		Timestamps: true,
+	}
+	if d.KeyColumnQuals["since_seconds"] != nil {
+		sinceSeconds := d.KeyColumnQuals["since_seconds"].GetInt64Value()
+		options.SinceSeconds = &sinceSeconds
+	}
+	if d.KeyColumnQuals["since_time"] != nil {
+		sinceTime := metav1.NewTime(d.KeyColumnQuals["since_time"].GetTimestampValue().AsTime())
+		options.SinceTime = &sinceTime
+	}
+	if d.KeyColumnQuals["tail_lines"] != nil {
+		tailLines := d.KeyColumnQuals["tail_lines"].GetInt64Value()
+		options.TailLines = &tailLines
+	}
+	previous := getBoolQualValue(d, "previous")
+	options.Previous = previous != nil && *previous
+	timestamps := getBoolQualValue(d, "timestamps")
+	keepTimestamps := timestamps != nil && *timestamps
+
+	containers := []string{}
+	if containerName := d.KeyColumnQualString("container_name"); containerName != "" {
+		containers = append(containers, containerName)
+	} else {
+		pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			if isNotFoundError(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		for _, container := range pod.Spec.InitContainers {
+			containers = append(containers, container.Name)
+		}
+		for _, container := range pod.Spec.Containers {
+			containers = append(containers, container.Name)
+		}
+		for _, container := range pod.Spec.EphemeralContainers {
+			containers = append(containers, container.Name)
+		}
+	}
+
+	for _, containerName := range containers {
+		options.Container = containerName
+
+		stream, err := clientset.CoreV1().Pods(namespace).GetLogs(podName, &options).Stream(ctx)
+		if err != nil {
+			if isNotFoundError(err) {
+				return nil, nil
+			}
+			// e.g. a container which has not started, or has no previous instance
+			if apierrors.IsBadRequest(err) && len(containers) > 1 {
+				logger.Debug("listK8sPodLogs", "container", containerName, "log_error", err)
+				continue
+			}
+			return nil, err
+		}
+
+		done, err := streamPodLogLines(ctx, d, stream, PodLogLine{Namespace: namespace, PodName: podName, ContainerName: containerName}, keepTimestamps)
+		stream.Close()
+		if err != nil || done {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// streamPodLogLines :: stream a row per line of the log. done is true when the query
+// needs no more rows, e.g. because its limit has been hit.
+func streamPodLogLines(ctx context.Context, d *plugin.QueryData, stream io.Reader, row PodLogLine, keepTimestamps bool) (done bool, err error) {
+	reader := bufio.NewReader(stream)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			row.LineNumber++
+			row.Timestamp, row.Log = parsePodLogLine(strings.TrimSuffix(line, "\n"), keepTimestamps)
+			d.StreamListItem(ctx, row)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return true, nil
+			}
+		}
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// parsePodLogLine :: split the RFC 3339 timestamp the kubelet prefixes each line with from the log
+func parsePodLogLine(line string, keepTimestamp bool) (*time.Time, string) {
+	prefix, log, found := strings.Cut(line, " ")
+	if !found {
+		prefix = line
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
+		return nil, line
+	}
+	if keepTimestamp {
+		return &timestamp, line
+	}
+	return &timestamp, log
+}
+
+// getBoolQualValue :: value of a boolean qual, taking `<>` into account, or nil if it is not set
+func getBoolQualValue(d *plugin.QueryData, column string) *bool {
+	quals := d.Quals[column]
+	if quals == nil {
+		return nil
+	}
+
+	for _, qual := range quals.Quals {
+		value := qual.Value.GetBoolValue()
+		if qual.Operator == "<>" {
+			value = !value
+		}
+		return &value
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePodLogLine(t *testing.T) {
+	tests := []struct {
+		name          string
+		line          string
+		keepTimestamp bool
+		wantTimestamp string
+		wantLog       string
+	}{
+		{
+			name:          "rfc3339nano prefix",
+			line:          "2022-10-05T14:03:12.123456789Z GET /healthz 200",
+			wantTimestamp: "2022-10-05T14:03:12.123456789Z",
+			wantLog:       "GET /healthz 200",
+		},
+		{
+			name:          "rfc3339 prefix without fractional seconds",
+			line:          "2022-10-05T14:03:12Z starting server",
+			wantTimestamp: "2022-10-05T14:03:12Z",
+			wantLog:       "starting server",
+		},
+		{
+			name:          "prefix with a zone offset",
+			line:          "2022-10-05T16:03:12.5+02:00 starting server",
+			wantTimestamp: "2022-10-05T14:03:12.5Z",
+			wantLog:       "starting server",
+		},
+		{
+			name:          "timestamp is kept",
+			line:          "2022-10-05T14:03:12.123456789Z GET /healthz 200",
+			keepTimestamp: true,
+			wantTimestamp: "2022-10-05T14:03:12.123456789Z",
+			wantLog:       "2022-10-05T14:03:12.123456789Z GET /healthz 200",
+		},
+		{
+			name:          "spaces after the prefix are preserved",
+			line:          "2022-10-05T14:03:12.1Z   indented",
+			wantTimestamp: "2022-10-05T14:03:12.1Z",
+			wantLog:       "  indented",
+		},
+		{
+			name:          "empty line after the prefix",
+			line:          "2022-10-05T14:03:12.1Z ",
+			wantTimestamp: "2022-10-05T14:03:12.1Z",
+			wantLog:       "",
+		},
+		{
+			name:          "prefix without a line",
+			line:          "2022-10-05T14:03:12.1Z",
+			wantTimestamp: "2022-10-05T14:03:12.1Z",
+			wantLog:       "",
+		},
+		{
+			name:    "line without a timestamp",
+			line:    "panic: runtime error: invalid memory address",
+			wantLog: "panic: runtime error: invalid memory address",
+		},
+		{
+			name:          "line without a timestamp is unchanged when keeping timestamps",
+			line:          "panic: runtime error: invalid memory address",
+			keepTimestamp: true,
+			wantLog:       "panic: runtime error: invalid memory address",
+		},
+		{
+			name:    "line starting with another date format",
+			line:    "2022/10/05 14:03:12 starting server",
+			wantLog: "2022/10/05 14:03:12 starting server",
+		},
+		{
+			name:    "single word",
+			line:    "ready",
+			wantLog: "ready",
+		},
+		{
+			name:    "empty line",
+			line:    "",
+			wantLog: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			timestamp, log := parsePodLogLine(test.line, test.keepTimestamp)
+
+			if test.wantTimestamp == "" {
+				if timestamp != nil {
+					t.Errorf("parsePodLogLine() timestamp = %s, want nil", timestamp)
+				}
+			} else {
+				want, err := time.Parse(time.RFC3339Nano, test.wantTimestamp)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if timestamp == nil || !timestamp.Equal(want) {
+					t.Errorf("parsePodLogLine() timestamp = %v, want %s", timestamp, want)
+				}
+			}
+
+			if log != test.wantLog {
+				t.Errorf("parsePodLogLine() log = %q, want %q", log, test.wantLog)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package kubernetes
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// PodMetric is a row of the kubernetes_pod_metric table, the usage of one container of a pod
+type PodMetric struct {
+	metricsv1beta1.ContainerMetrics
+	PodName   string
+	Namespace string
+	Labels    map[string]string
+	Timestamp metav1.Time
+	Window    metav1.Duration
+}
+
+func tableKubernetesPodMetric(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_pod_metric",
+		Description:       "CPU and memory usage of the containers of each pod, from the metrics.k8s.io API served by metrics-server, with one row per container. No rows are returned if the API is not installed.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
+		List: &plugin.ListConfig{
+			Hydrate: listK8sPodMetrics,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "namespace", Require: plugin.Optional},
+				{Name: "pod_name", Require: plugin.Optional},
+				{Name: "selector_search", Require: plugin.Optional},
+			},
+		},
+		Columns: append([]*plugin.Column{
+			{
+				Name:        "namespace",
+				Type:        proto.ColumnType_STRING,
+				Description: "Namespace of the pod.",
+			},
+			{
+				Name:        "pod_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the pod.",
+			},
+			{
+				Name:        "container_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the container.",
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "cpu_usage_millicores",
+				Type:        proto.ColumnType_INT,
+				Description: "CPU used by the container over the sample window, in millicores.",
+				Transform:   transform.FromField("Usage").TransformP(transformMetricsUsage, v1.ResourceCPU),
+			},
+			{
+				Name:        "memory_usage_bytes",
+				Type:        proto.ColumnType_INT,
+				Description: "Working set memory of the container at the end of the sample window, in bytes.",
+				Transform:   transform.FromField("Usage").TransformP(transformMetricsUsage, v1.ResourceMemory),
+			},
+			{
+				Name:        "window_seconds",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "Length of the window the usage was sampled over, in seconds.",
+				Transform:   transform.FromField("Window").Transform(transformMetricsWindow),
+			},
+			{
+				Name:        "timestamp",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time at the end of the sample window.",
+				Transform:   transform.FromField("Timestamp").Transform(v1TimeToRFC3339),
+			},
+			{
+				Name:        "labels",
+				Type:        proto.ColumnType_JSON,
+				Description: "Labels of the pod.",
+			},
+			{
+				Name:        "selector_search",
+				Type:        proto.ColumnType_STRING,
+				Description: "A label selector string to restrict the pods listed, e.g. app=web.",
+				Transform:   transform.FromQual("selector_search"),
+			},
+		}, kubectlConfigColumns()...),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sPodMetrics(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sPodMetrics")
+
+	// manifest sources have no metrics
+	if isManifestSource(d) {
+		return nil, nil
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	clientset, err := GetNewClientMetrics(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	podName := d.KeyColumnQualString("pod_name")
+	if podName != "" && namespace != "" {
+		metrics, err := clientset.MetricsV1beta1().PodMetricses(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			// also covers a pod without metrics
+			if isMetricsAPIUnavailable(ctx, err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		streamPodMetricContainers(ctx, d, *metrics)
+		return nil, nil
+	}
+
+	input := metav1.ListOptions{}
+	if d.KeyColumnQuals["selector_search"] != nil {
+		input.LabelSelector = d.KeyColumnQuals["selector_search"].GetStringValue()
+	}
+
+	// metrics-server answers from memory and does not page
+	response, err := clientset.MetricsV1beta1().PodMetricses(namespace).List(ctx, input)
+	if err != nil {
+		// fall back to the namespaces the caller can access
+		if isClusterListForbidden(err, namespace, input) {
+			return listAccessibleNamespaces(ctx, d, listK8sPodMetrics, err)
+		}
+		if isMetricsAPIUnavailable(ctx, err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, metrics := range response.Items {
+		if podName != "" && metrics.Name != podName {
+			continue
+		}
+		if done := streamPodMetricContainers(ctx, d, metrics); done {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// streamPodMetricContainers :: stream a row per container of the pod. done is true when
+// the query needs no more rows, e.g. because its limit has been hit.
+func streamPodMetricContainers(ctx context.Context, d *plugin.QueryData, metrics metricsv1beta1.PodMetrics) (done bool) {
+	for _, container := range metrics.Containers {
+		d.StreamListItem(ctx, PodMetric{
+			ContainerMetrics: container,
+			PodName:          metrics.Name,
+			Namespace:        metrics.Namespace,
+			Labels:           metrics.Labels,
+			Timestamp:        metrics.Timestamp,
+			Window:           metrics.Window,
+		})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			return true
+		}
+	}
+	return false
+}
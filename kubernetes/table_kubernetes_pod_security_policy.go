@@ -2,26 +2,38 @@ package kubernetes
 
 import (
 	"context"
+	"strings"
 
 	v1beta1 "k8s.io/api/policy/v1beta1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// PodSecurityPolicy is a row of the kubernetes_pod_security_policy table
+type PodSecurityPolicy struct {
+	v1beta1.PodSecurityPolicy
+	sourceInfo
+}
+
 func tableKubernetesPodSecurityPolicy(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_pod_security_policy",
-		Description: "A Pod Security Policy is a cluster-level resource that controls security sensitive aspects of the pod specification. The PodSecurityPolicy objects define a set of conditions that a pod must run with in order to be accepted into the system, as well as defaults for the related fields.",
+		Name:              "kubernetes_pod_security_policy",
+		Description:       "A Pod Security Policy is a cluster-level resource that controls security sensitive aspects of the pod specification. The PodSecurityPolicy objects define a set of conditions that a pod must run with in order to be accepted into the system, as well as defaults for the related fields.",
+		GetMatrixItemFunc: BuildContextList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.SingleColumn("name"),
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
 			Hydrate:    getPodSecurityPolicy,
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listPodSecurityPolicy,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
 		},
 		// PodSecurityPolicy, is a non-namespaced resource.
 		Columns: k8sCommonGlobalColumns([]*plugin.Column{
@@ -191,6 +203,10 @@ func listPodSecurityPolicy(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 	logger := plugin.Logger(ctx)
 	logger.Trace("listPodSecurityPolicy")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newPodSecurityPolicyRow, schema.GroupKind{Group: "policy", Kind: "PodSecurityPolicy"})
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -212,6 +228,12 @@ func listPodSecurityPolicy(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 		}
 	}
 
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
 	var response *v1beta1.PodSecurityPolicyList
 	pageLeft := true
 
@@ -228,7 +250,7 @@ func listPodSecurityPolicy(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 		}
 
 		for _, podSecurityPolicy := range response.Items {
-			d.StreamListItem(ctx, podSecurityPolicy)
+			d.StreamListItem(ctx, PodSecurityPolicy{PodSecurityPolicy: podSecurityPolicy})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -244,6 +266,10 @@ func getPodSecurityPolicy(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 	logger := plugin.Logger(ctx)
 	logger.Trace("getPodSecurityPolicy")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newPodSecurityPolicyRow, schema.GroupKind{Group: "policy", Kind: "PodSecurityPolicy"})
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -261,12 +287,21 @@ func getPodSecurityPolicy(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 		return nil, err
 	}
 
-	return *podSecurityPolicy, nil
+	return PodSecurityPolicy{PodSecurityPolicy: *podSecurityPolicy}, nil
+}
+
+func newPodSecurityPolicyRow(manifest manifestObject) (interface{}, error) {
+	var obj v1beta1.PodSecurityPolicy
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return PodSecurityPolicy{PodSecurityPolicy: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformPodSecurityPolicyTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1beta1.PodSecurityPolicy)
+	obj := d.HydrateItem.(PodSecurityPolicy)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
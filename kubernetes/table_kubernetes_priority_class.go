@@ -0,0 +1,187 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+
+	v1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// PriorityClass is a row of the kubernetes_priority_class table
+type PriorityClass struct {
+	v1.PriorityClass
+	sourceInfo
+}
+
+func tableKubernetesPriorityClass(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_priority_class",
+		Description:       "A PriorityClass maps a priority class name to the integer priority of pods which use it. Pods of higher priority are scheduled first, and may preempt pods of lower priority.",
+		GetMatrixItemFunc: BuildContextList,
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
+			Hydrate:    getK8sPriorityClass,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listK8sPriorityClasses,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
+		},
+		Columns: k8sCommonGlobalColumns([]*plugin.Column{
+			{
+				Name:        "value",
+				Type:        proto.ColumnType_INT,
+				Description: "Priority of pods which use the class. Higher values are more important.",
+			},
+			{
+				Name:        "global_default",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the class is used for pods which do not name a priority class.",
+			},
+			{
+				Name:        "description",
+				Type:        proto.ColumnType_STRING,
+				Description: "Description of when the class should be used.",
+			},
+			{
+				Name:        "preemption_policy",
+				Type:        proto.ColumnType_STRING,
+				Description: "Whether pods of the class may preempt pods of lower priority: PreemptLowerPriority (the default) or Never.",
+				Transform:   transform.FromField("PreemptionPolicy"),
+			},
+
+			//// Steampipe Standard Columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: ColumnDescriptionTitle,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Type:        proto.ColumnType_JSON,
+				Description: ColumnDescriptionTags,
+				Transform:   transform.From(transformPriorityClassTags),
+			},
+		}),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sPriorityClasses(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sPriorityClasses")
+
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newPriorityClassRow, schema.GroupKind{Group: "scheduling.k8s.io", Kind: "PriorityClass"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	// Limiting the results
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < input.Limit {
+			if *limit < 1 {
+				input.Limit = 1
+			} else {
+				input.Limit = *limit
+			}
+		}
+	}
+
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
+	var response *v1.PriorityClassList
+	pageLeft := true
+
+	for pageLeft {
+		response, err = clientset.SchedulingV1().PriorityClasses().List(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+		} else {
+			pageLeft = false
+		}
+
+		for _, priorityClass := range response.Items {
+			d.StreamListItem(ctx, PriorityClass{PriorityClass: priorityClass})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getK8sPriorityClass(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("getK8sPriorityClass")
+
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newPriorityClassRow, schema.GroupKind{Group: "scheduling.k8s.io", Kind: "PriorityClass"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	name := d.KeyColumnQuals["name"].GetStringValue()
+
+	// return if name is empty
+	if name == "" {
+		return nil, nil
+	}
+
+	priorityClass, err := clientset.SchedulingV1().PriorityClasses().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return PriorityClass{PriorityClass: *priorityClass}, nil
+}
+
+func newPriorityClassRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.PriorityClass
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return PriorityClass{PriorityClass: obj, sourceInfo: manifest.sourceInfo}, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func transformPriorityClassTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(PriorityClass)
+	return mergeTags(obj.Labels, obj.Annotations), nil
+}
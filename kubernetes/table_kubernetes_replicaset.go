@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// ReplicaSet is a row of the kubernetes_replicaset table
+type ReplicaSet struct {
+	v1.ReplicaSet
+	sourceInfo
+}
+
 func tableKubernetesReplicaSet(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_replicaset",
-		Description: "Kubernetes replica set ensures that a specified number of pod replicas are running at any given time.",
+		Name:              "kubernetes_replicaset",
+		Description:       "Kubernetes replica set ensures that a specified number of pod replicas are running at any given time.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sReplicaSet,
 		},
 		List: &plugin.ListConfig{
@@ -119,6 +127,15 @@ func listK8sReplicaSets(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sReplicaSets")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newReplicaSetRow, schema.GroupKind{Group: "apps", Kind: "ReplicaSet"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -150,8 +167,12 @@ func listK8sReplicaSets(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.AppsV1().ReplicaSets("").List(ctx, input)
+		response, err = clientset.AppsV1().ReplicaSets(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sReplicaSets, err)
+			}
 			return nil, err
 		}
 
@@ -162,7 +183,7 @@ func listK8sReplicaSets(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		}
 
 		for _, item := range response.Items {
-			d.StreamListItem(ctx, item)
+			d.StreamListItem(ctx, ReplicaSet{ReplicaSet: item})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -178,6 +199,14 @@ func getK8sReplicaSet(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrat
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sReplicaSet")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newReplicaSetRow, schema.GroupKind{Group: "apps", Kind: "ReplicaSet"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -196,12 +225,21 @@ func getK8sReplicaSet(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrat
 		return nil, err
 	}
 
-	return *rs, nil
+	return ReplicaSet{ReplicaSet: *rs}, nil
+}
+
+func newReplicaSetRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.ReplicaSet
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return ReplicaSet{ReplicaSet: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformReplicaSetTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.ReplicaSet)
+	obj := d.HydrateItem.(ReplicaSet)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
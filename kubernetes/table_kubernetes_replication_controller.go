@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// ReplicationController is a row of the kubernetes_replication_controller table
+type ReplicationController struct {
+	v1.ReplicationController
+	sourceInfo
+}
+
 func tableKubernetesReplicaController(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_replication_controller",
-		Description: "A Replication Controller makes sure that a pod or homogeneous set of pods are always up and available. If there are too many pods, it will kill some. If there are too few, the Replication Controller will start more.",
+		Name:              "kubernetes_replication_controller",
+		Description:       "A Replication Controller makes sure that a pod or homogeneous set of pods are always up and available. If there are too many pods, it will kill some. If there are too few, the Replication Controller will start more.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sReplicaController,
 		},
 		List: &plugin.ListConfig{
@@ -119,6 +127,15 @@ func listK8sReplicaControllers(ctx context.Context, d *plugin.QueryData, _ *plug
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sReplicaControllers")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newReplicationControllerRow, schema.GroupKind{Kind: "ReplicationController"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -150,8 +167,12 @@ func listK8sReplicaControllers(ctx context.Context, d *plugin.QueryData, _ *plug
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.CoreV1().ReplicationControllers("").List(ctx, input)
+		response, err = clientset.CoreV1().ReplicationControllers(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sReplicaControllers, err)
+			}
 			return nil, err
 		}
 
@@ -162,7 +183,7 @@ func listK8sReplicaControllers(ctx context.Context, d *plugin.QueryData, _ *plug
 		}
 
 		for _, replicaController := range response.Items {
-			d.StreamListItem(ctx, replicaController)
+			d.StreamListItem(ctx, ReplicationController{ReplicationController: replicaController})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -178,6 +199,14 @@ func getK8sReplicaController(ctx context.Context, d *plugin.QueryData, _ *plugin
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sReplicaController")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newReplicationControllerRow, schema.GroupKind{Kind: "ReplicationController"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -196,12 +225,21 @@ func getK8sReplicaController(ctx context.Context, d *plugin.QueryData, _ *plugin
 		return nil, err
 	}
 
-	return *replicaController, nil
+	return ReplicationController{ReplicationController: *replicaController}, nil
+}
+
+func newReplicationControllerRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.ReplicationController
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return ReplicationController{ReplicationController: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformReplicaControllerTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.ReplicationController)
+	obj := d.HydrateItem.(ReplicationController)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
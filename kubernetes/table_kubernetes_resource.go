@@ -0,0 +1,384 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// apiResource is a resource resolved through API discovery
+type apiResource struct {
+	schema.GroupVersionResource
+	Kind       string
+	Namespaced bool
+}
+
+func tableKubernetesResource(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_resource",
+		Description:       "Objects of any resource served by the cluster, selected by api_version and kind, or by resource name.",
+		GetMatrixItemFunc: BuildContextList,
+		List: &plugin.ListConfig{
+			Hydrate: listK8sResources,
+			KeyColumns: getOptionalKeyQualWithCommonKeyQuals([]*plugin.KeyColumn{
+				{Name: "api_version", Require: plugin.AnyOf},
+				{Name: "kind", Require: plugin.AnyOf},
+				{Name: "resource", Require: plugin.AnyOf},
+			}),
+		},
+		Columns: k8sCommonColumns([]*plugin.Column{
+			{
+				Name:        "api_version",
+				Type:        proto.ColumnType_STRING,
+				Description: "API version of the object, e.g. coordination.k8s.io/v1. Required unless resource is given, in which case it selects the version the resource is read in instead of the preferred version of its group.",
+				Transform:   transform.FromField("APIVersion"),
+			},
+			{
+				Name:        "kind",
+				Type:        proto.ColumnType_STRING,
+				Description: "Kind of the object, e.g. Lease. Required unless resource is given.",
+			},
+			{
+				Name:        "resource",
+				Type:        proto.ColumnType_STRING,
+				Description: "Resource queried, as <resource>.<group>, e.g. leases.coordination.k8s.io, or <resource> for the core group. Singular and short names are also accepted.",
+				Transform:   transform.FromQual("resource"),
+			},
+			{
+				Name:        "spec",
+				Type:        proto.ColumnType_JSON,
+				Description: "Spec of the object, if it has one.",
+			},
+			{
+				Name:        "status",
+				Type:        proto.ColumnType_JSON,
+				Description: "Status of the object, if it has one.",
+			},
+			{
+				Name:        "object",
+				Type:        proto.ColumnType_JSON,
+				Description: "The complete object.",
+			},
+
+			//// Steampipe Standard Columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: ColumnDescriptionTitle,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Type:        proto.ColumnType_JSON,
+				Description: ColumnDescriptionTags,
+				Transform:   transform.From(transformCustomResourceTags),
+			},
+		}),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sResources(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sResources")
+
+	apiVersion := d.KeyColumnQualString("api_version")
+	kind := d.KeyColumnQualString("kind")
+	resourceName := d.KeyColumnQualString("resource")
+
+	if err := validateResourceQuals(apiVersion, kind, resourceName); err != nil {
+		return nil, err
+	}
+
+	if isManifestSource(d) {
+		return nil, streamManifestResources(ctx, d, apiVersion, kind, resourceName)
+	}
+
+	resource, err := resolveAPIResource(ctx, d, apiVersion, kind, resourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := d.KeyColumnQualString("namespace")
+
+	// cluster-scoped objects have no namespace to match
+	if !resource.Namespaced && namespace != "" {
+		return nil, nil
+	}
+
+	// namespaced resources are subject to the connection's namespace filters
+	kubernetesConfig := GetConfig(d.Connection)
+	if resource.Namespaced && hasNamespaceFilter(kubernetesConfig) {
+		if namespace == "" {
+			namespaces, err := getAllowedNamespaces(ctx, d, getContextName(ctx, d))
+			if err != nil {
+				return nil, err
+			}
+			for _, err := range listNamespaces(ctx, d, listK8sResources, namespaces) {
+				if err != nil {
+					return nil, err
+				}
+			}
+			return nil, nil
+		}
+		if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+			return nil, err
+		}
+	}
+
+	client, err := GetNewClientDynamic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	// Limiting the results
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < input.Limit {
+			if *limit < 1 {
+				input.Limit = 1
+			} else {
+				input.Limit = *limit
+			}
+		}
+	}
+
+	if name := d.KeyColumnQualString("name"); name != "" {
+		input.FieldSelector = fmt.Sprintf("metadata.name=%v", name)
+	}
+
+	resourceClient := client.Resource(resource.GroupVersionResource)
+
+	pageLeft := true
+	for pageLeft {
+		response, err := resourceClient.Namespace(namespace).List(ctx, input)
+		if err != nil {
+			// fall back to the namespaces the caller can access
+			if resource.Namespaced && isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sResources, err)
+			}
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.GetContinue()
+		} else {
+			pageLeft = false
+		}
+
+		for _, item := range response.Items {
+			row, err := newCustomResource(item.Object, sourceInfo{})
+			if err != nil {
+				return nil, err
+			}
+			d.StreamListItem(ctx, row)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// validateResourceQuals :: the list key columns can only require any of api_version, kind and
+// resource, so a query with api_version or kind alone is planned and rejected here, before
+// any API call is made
+func validateResourceQuals(apiVersion string, kind string, resourceName string) error {
+	if resourceName != "" {
+		return nil
+	}
+
+	switch {
+	case apiVersion == "" && kind == "":
+		return fmt.Errorf("kubernetes_resource must be queried with both api_version and kind, or with resource")
+	case kind == "":
+		return fmt.Errorf("kubernetes_resource must be queried with kind as well as api_version, or with resource")
+	case apiVersion == "":
+		return fmt.Errorf("kubernetes_resource must be queried with api_version as well as kind, or with resource")
+	}
+
+	return nil
+}
+
+// streamManifestResources :: stream the manifest objects of the queried resource. Without
+// discovery, a resource qual is matched against the plural of each object's kind.
+func streamManifestResources(ctx context.Context, d *plugin.QueryData, apiVersion string, kind string, resourceName string) error {
+	manifests, err := getCachedManifestObjects(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	groupResource := schema.ParseGroupResource(resourceName)
+
+	for _, manifest := range manifests {
+		gvk := schema.FromAPIVersionAndKind(stringValue(manifest.Object["apiVersion"]), stringValue(manifest.Object["kind"]))
+
+		if resourceName != "" {
+			plural, singular := meta.UnsafeGuessKindToResource(gvk)
+			if gvk.Group != groupResource.Group || (plural.Resource != groupResource.Resource && singular.Resource != groupResource.Resource) {
+				continue
+			}
+		}
+		if apiVersion != "" && gvk.GroupVersion().String() != apiVersion {
+			continue
+		}
+		if kind != "" && gvk.Kind != kind {
+			continue
+		}
+
+		metadata, _ := manifest.Object["metadata"].(map[string]interface{})
+		if name := d.KeyColumnQualString("name"); name != "" && stringValue(metadata["name"]) != name {
+			continue
+		}
+		if namespace := d.KeyColumnQualString("namespace"); namespace != "" && stringValue(metadata["namespace"]) != namespace {
+			continue
+		}
+		if stringValue(metadata["namespace"]) != "" {
+			allowed, err := isNamespaceAllowed(GetConfig(d.Connection), stringValue(metadata["namespace"]))
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				continue
+			}
+		}
+
+		row, err := newCustomResource(manifest.Object, manifest.sourceInfo)
+		if err != nil {
+			return fmt.Errorf("%s: %v", manifest.Path, err)
+		}
+		d.StreamListItem(ctx, row)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// resolveAPIResource :: look up the queried resource with API discovery, either by API
+// version and kind, or by a resource name in the given API version or, without one, in the
+// preferred version of its group
+func resolveAPIResource(ctx context.Context, d *plugin.QueryData, apiVersion string, kind string, resourceName string) (*apiResource, error) {
+	contextName := getContextName(ctx, d)
+
+	// have we already resolved the resource for this context?
+	cacheKey := strings.Join([]string{"resolveAPIResource", contextName, apiVersion, kind, resourceName}, "-")
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*apiResource), nil
+	}
+
+	clientset, err := getNewClientsetForContext(ctx, d.Connection, contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := findAPIResource(clientset.Discovery(), apiVersion, kind, resourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	// save the resource in cache
+	d.ConnectionManager.Cache.Set(cacheKey, resource)
+
+	return resource, nil
+}
+
+// findAPIResource :: the resource matching the quals among those served by the discovery client
+func findAPIResource(discoveryClient discovery.DiscoveryInterface, apiVersion string, kind string, resourceName string) (*apiResource, error) {
+	groupResource := schema.ParseGroupResource(resourceName)
+
+	if resourceName != "" && apiVersion != "" {
+		groupVersion, err := schema.ParseGroupVersion(apiVersion)
+		if err != nil {
+			return nil, err
+		}
+		if groupVersion.Group != groupResource.Group {
+			return nil, fmt.Errorf("resource %q is not in the API group of %s", resourceName, apiVersion)
+		}
+	} else if resourceName != "" {
+		groups, err := discoveryClient.ServerGroups()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, group := range groups.Groups {
+			if group.Name == groupResource.Group {
+				apiVersion = group.PreferredVersion.GroupVersion
+				break
+			}
+		}
+		if apiVersion == "" {
+			return nil, fmt.Errorf("the server has no API group %q for resource %q", groupResource.Group, resourceName)
+		}
+	}
+
+	groupVersion, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(apiVersion)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("the server has no API version %s", apiVersion)
+		}
+		return nil, err
+	}
+
+	var resource *apiResource
+	for _, r := range resources.APIResources {
+		// subresources, e.g. pods/log, are not listable objects
+		if strings.Contains(r.Name, "/") {
+			continue
+		}
+
+		var match bool
+		if resourceName != "" {
+			match = r.Name == groupResource.Resource || r.SingularName == groupResource.Resource || containsAny(r.ShortNames, groupResource.Resource)
+			if kind != "" {
+				match = match && r.Kind == kind
+			}
+		} else {
+			match = r.Kind == kind
+		}
+
+		if match {
+			resource = &apiResource{
+				GroupVersionResource: groupVersion.WithResource(r.Name),
+				Kind:                 r.Kind,
+				Namespaced:           r.Namespaced,
+			}
+			break
+		}
+	}
+
+	if resource == nil {
+		if resourceName != "" {
+			return nil, fmt.Errorf("the server has no resource %q in %s", resourceName, apiVersion)
+		}
+		return nil, fmt.Errorf("the server has no resource of kind %q in %s", kind, apiVersion)
+	}
+
+	return resource, nil
+}
@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// ResourceQuota is a row of the kubernetes_resource_quota table
+type ResourceQuota struct {
+	v1.ResourceQuota
+	sourceInfo
+}
+
 func tableKubernetesResourceQuota(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_resource_quota",
-		Description: "Kubernetes Resource Quota",
+		Name:              "kubernetes_resource_quota",
+		Description:       "Kubernetes Resource Quota",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sResourceQuota,
 		},
 		List: &plugin.ListConfig{
@@ -82,6 +90,15 @@ func tableKubernetesResourceQuota(ctx context.Context) *plugin.Table {
 func listK8sResourceQuotas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("listK8sResourceQuotas")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newResourceQuotaRow, schema.GroupKind{Kind: "ResourceQuota"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -113,8 +130,12 @@ func listK8sResourceQuotas(ctx context.Context, d *plugin.QueryData, h *plugin.H
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.CoreV1().ResourceQuotas("").List(ctx, input)
+		response, err = clientset.CoreV1().ResourceQuotas(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sResourceQuotas, err)
+			}
 			return nil, err
 		}
 
@@ -125,7 +146,7 @@ func listK8sResourceQuotas(ctx context.Context, d *plugin.QueryData, h *plugin.H
 		}
 
 		for _, resourceQuota := range response.Items {
-			d.StreamListItem(ctx, resourceQuota)
+			d.StreamListItem(ctx, ResourceQuota{ResourceQuota: resourceQuota})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -140,6 +161,14 @@ func listK8sResourceQuotas(ctx context.Context, d *plugin.QueryData, h *plugin.H
 func getK8sResourceQuota(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("getK8sResourceQuota")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newResourceQuotaRow, schema.GroupKind{Kind: "ResourceQuota"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -158,12 +187,21 @@ func getK8sResourceQuota(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 		return nil, err
 	}
 
-	return *resourceQuota, nil
+	return ResourceQuota{ResourceQuota: *resourceQuota}, nil
+}
+
+func newResourceQuotaRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.ResourceQuota
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return ResourceQuota{ResourceQuota: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformResourceQuotaTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.ResourceQuota)
+	obj := d.HydrateItem.(ResourceQuota)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
@@ -0,0 +1,143 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestValidateResourceQuals(t *testing.T) {
+	tests := []struct {
+		name         string
+		apiVersion   string
+		kind         string
+		resourceName string
+		wantErr      bool
+	}{
+		{name: "api_version and kind", apiVersion: "apps/v1", kind: "Deployment"},
+		{name: "resource", resourceName: "deployments.apps"},
+		{name: "resource and api_version", apiVersion: "apps/v1", resourceName: "deployments.apps"},
+		{name: "resource and kind", kind: "Deployment", resourceName: "deployments.apps"},
+		{name: "no quals", wantErr: true},
+		{name: "api_version only", apiVersion: "apps/v1", wantErr: true},
+		{name: "kind only", kind: "Deployment", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateResourceQuals(test.apiVersion, test.kind, test.resourceName)
+			if (err != nil) != test.wantErr {
+				t.Errorf("validateResourceQuals() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestFindAPIResource(t *testing.T) {
+	discoveryClient := fake.NewSimpleClientset().Discovery().(*fakediscovery.FakeDiscovery)
+	discoveryClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", SingularName: "pod", Kind: "Pod", Namespaced: true, ShortNames: []string{"po"}},
+				{Name: "pods/log", Kind: "Pod", Namespaced: true},
+				{Name: "nodes", SingularName: "node", Kind: "Node", ShortNames: []string{"no"}},
+			},
+		},
+		{
+			GroupVersion: "coordination.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "leases", SingularName: "lease", Kind: "Lease", Namespaced: true},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		apiVersion   string
+		kind         string
+		resourceName string
+		want         *apiResource
+		wantErr      bool
+	}{
+		{
+			name:       "api_version and kind",
+			apiVersion: "coordination.k8s.io/v1",
+			kind:       "Lease",
+			want:       &apiResource{GroupVersionResource: schema.GroupVersionResource{Group: "coordination.k8s.io", Version: "v1", Resource: "leases"}, Kind: "Lease", Namespaced: true},
+		},
+		{
+			name:         "resource in the preferred version of its group",
+			resourceName: "leases.coordination.k8s.io",
+			want:         &apiResource{GroupVersionResource: schema.GroupVersionResource{Group: "coordination.k8s.io", Version: "v1", Resource: "leases"}, Kind: "Lease", Namespaced: true},
+		},
+		{
+			name:         "core resource by short name",
+			resourceName: "no",
+			want:         &apiResource{GroupVersionResource: schema.GroupVersionResource{Version: "v1", Resource: "nodes"}, Kind: "Node"},
+		},
+		{
+			name:         "core resource by singular name in a given version",
+			apiVersion:   "v1",
+			resourceName: "pod",
+			want:         &apiResource{GroupVersionResource: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, Kind: "Pod", Namespaced: true},
+		},
+		{
+			name:       "subresources are skipped",
+			apiVersion: "v1",
+			kind:       "Pod",
+			want:       &apiResource{GroupVersionResource: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, Kind: "Pod", Namespaced: true},
+		},
+		{
+			name:         "resource and kind which do not match",
+			resourceName: "pods",
+			kind:         "Node",
+			wantErr:      true,
+		},
+		{
+			name:         "resource outside the group of api_version",
+			apiVersion:   "coordination.k8s.io/v1",
+			resourceName: "pods",
+			wantErr:      true,
+		},
+		{
+			name:         "unknown group",
+			resourceName: "certificates.cert-manager.io",
+			wantErr:      true,
+		},
+		{
+			name:       "unknown api_version",
+			apiVersion: "coordination.k8s.io/v1beta1",
+			kind:       "Lease",
+			wantErr:    true,
+		},
+		{
+			name:       "unknown kind",
+			apiVersion: "v1",
+			kind:       "Lease",
+			wantErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := findAPIResource(discoveryClient, test.apiVersion, test.kind, test.resourceName)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("findAPIResource() = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findAPIResource() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("findAPIResource() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// Role is a row of the kubernetes_role table
+type Role struct {
+	v1.Role
+	sourceInfo
+}
+
 func tableKubernetesRole(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_role",
-		Description: "Role contains rules that represent a set of permissions.",
+		Name:              "kubernetes_role",
+		Description:       "Role contains rules that represent a set of permissions.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sRole,
 		},
 		List: &plugin.ListConfig{
@@ -54,6 +62,15 @@ func listK8sRoles(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sRoles")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newRoleRow, schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "Role"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -85,8 +102,12 @@ func listK8sRoles(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.RbacV1().Roles("").List(ctx, input)
+		response, err = clientset.RbacV1().Roles(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sRoles, err)
+			}
 			return nil, err
 		}
 
@@ -97,7 +118,7 @@ func listK8sRoles(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 		}
 
 		for _, role := range response.Items {
-			d.StreamListItem(ctx, role)
+			d.StreamListItem(ctx, Role{Role: role})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -113,6 +134,14 @@ func getK8sRole(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sRole")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newRoleRow, schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "Role"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -131,12 +160,21 @@ func getK8sRole(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 		return nil, err
 	}
 
-	return *role, nil
+	return Role{Role: *role}, nil
+}
+
+func newRoleRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.Role
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return Role{Role: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformRoleTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.Role)
+	obj := d.HydrateItem.(Role)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
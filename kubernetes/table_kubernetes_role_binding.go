@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// RoleBinding is a row of the kubernetes_role_binding table
+type RoleBinding struct {
+	v1.RoleBinding
+	sourceInfo
+}
+
 func tableKubernetesRoleBinding(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_role_binding",
-		Description: "A role binding grants the permissions defined in a role to a user or set of users. It holds a list of subjects (users, groups, or service accounts), and a reference to the role being granted.",
+		Name:              "kubernetes_role_binding",
+		Description:       "A role binding grants the permissions defined in a role to a user or set of users. It holds a list of subjects (users, groups, or service accounts), and a reference to the role being granted.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sRoleBinding,
 		},
 		List: &plugin.ListConfig{
@@ -74,6 +82,15 @@ func listK8sRoleBindings(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sRoleBindings")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newRoleBindingRow, schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -105,8 +122,12 @@ func listK8sRoleBindings(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.RbacV1().RoleBindings("").List(ctx, input)
+		response, err = clientset.RbacV1().RoleBindings(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sRoleBindings, err)
+			}
 			return nil, err
 		}
 
@@ -117,7 +138,7 @@ func listK8sRoleBindings(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 		}
 
 		for _, roleBinding := range response.Items {
-			d.StreamListItem(ctx, roleBinding)
+			d.StreamListItem(ctx, RoleBinding{RoleBinding: roleBinding})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -133,6 +154,14 @@ func getK8sRoleBinding(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sRoleBinding")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newRoleBindingRow, schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -151,12 +180,21 @@ func getK8sRoleBinding(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 		return nil, err
 	}
 
-	return *roleBinding, nil
+	return RoleBinding{RoleBinding: *roleBinding}, nil
+}
+
+func newRoleBindingRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.RoleBinding
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return RoleBinding{RoleBinding: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformRoleBindingTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.RoleBinding)
+	obj := d.HydrateItem.(RoleBinding)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
@@ -0,0 +1,189 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+
+	v1 "k8s.io/api/node/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// RuntimeClass is a row of the kubernetes_runtime_class table
+type RuntimeClass struct {
+	v1.RuntimeClass
+	sourceInfo
+}
+
+func tableKubernetesRuntimeClass(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_runtime_class",
+		Description:       "A RuntimeClass selects the container runtime configuration, such as a sandboxed runtime, used to run the containers of pods which name it.",
+		GetMatrixItemFunc: BuildContextList,
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
+			Hydrate:    getK8sRuntimeClass,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listK8sRuntimeClasses,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
+		},
+		Columns: k8sCommonGlobalColumns([]*plugin.Column{
+			{
+				Name:        "handler",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the container runtime configuration (CRI handler) which runs pods of this class, e.g. runc or gvisor.",
+			},
+			{
+				Name:        "overhead",
+				Type:        proto.ColumnType_JSON,
+				Description: "Resources used by the runtime itself, added to the resource requests of pods of this class.",
+				Transform:   transform.FromField("Overhead.PodFixed"),
+			},
+			{
+				Name:        "scheduling_node_selector",
+				Type:        proto.ColumnType_JSON,
+				Description: "Node labels required to run pods of this class, merged into the node selector of each pod.",
+				Transform:   transform.FromField("Scheduling.NodeSelector"),
+			},
+			{
+				Name:        "scheduling_tolerations",
+				Type:        proto.ColumnType_JSON,
+				Description: "Tolerations added to pods of this class, to allow them onto nodes with matching taints.",
+				Transform:   transform.FromField("Scheduling.Tolerations"),
+			},
+
+			//// Steampipe Standard Columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: ColumnDescriptionTitle,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Type:        proto.ColumnType_JSON,
+				Description: ColumnDescriptionTags,
+				Transform:   transform.From(transformRuntimeClassTags),
+			},
+		}),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sRuntimeClasses(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sRuntimeClasses")
+
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newRuntimeClassRow, schema.GroupKind{Group: "node.k8s.io", Kind: "RuntimeClass"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	// Limiting the results
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < input.Limit {
+			if *limit < 1 {
+				input.Limit = 1
+			} else {
+				input.Limit = *limit
+			}
+		}
+	}
+
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
+	var response *v1.RuntimeClassList
+	pageLeft := true
+
+	for pageLeft {
+		response, err = clientset.NodeV1().RuntimeClasses().List(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+		} else {
+			pageLeft = false
+		}
+
+		for _, runtimeClass := range response.Items {
+			d.StreamListItem(ctx, RuntimeClass{RuntimeClass: runtimeClass})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getK8sRuntimeClass(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("getK8sRuntimeClass")
+
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newRuntimeClassRow, schema.GroupKind{Group: "node.k8s.io", Kind: "RuntimeClass"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	name := d.KeyColumnQuals["name"].GetStringValue()
+
+	// return if name is empty
+	if name == "" {
+		return nil, nil
+	}
+
+	runtimeClass, err := clientset.NodeV1().RuntimeClasses().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return RuntimeClass{RuntimeClass: *runtimeClass}, nil
+}
+
+func newRuntimeClassRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.RuntimeClass
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return RuntimeClass{RuntimeClass: obj, sourceInfo: manifest.sourceInfo}, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func transformRuntimeClassTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(RuntimeClass)
+	return mergeTags(obj.Labels, obj.Annotations), nil
+}
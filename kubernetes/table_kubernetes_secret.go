@@ -7,18 +7,26 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// Secret is a row of the kubernetes_secret table
+type Secret struct {
+	v1.Secret
+	sourceInfo
+}
+
 func tableKubernetesSecret(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_secret",
-		Description: "Secrets can be used to store sensitive information either as individual properties or coarse-grained entries like entire files or JSON blobs.",
+		Name:              "kubernetes_secret",
+		Description:       "Secrets can be used to store sensitive information either as individual properties or coarse-grained entries like entire files or JSON blobs.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sSecret,
 		},
 		List: &plugin.ListConfig{
@@ -73,6 +81,15 @@ func listK8sSecrets(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sSecrets")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newSecretRow, schema.GroupKind{Kind: "Secret"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -108,8 +125,12 @@ func listK8sSecrets(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.CoreV1().Secrets("").List(ctx, input)
+		response, err = clientset.CoreV1().Secrets(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sSecrets, err)
+			}
 			return nil, err
 		}
 
@@ -120,7 +141,7 @@ func listK8sSecrets(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 		}
 
 		for _, secret := range response.Items {
-			d.StreamListItem(ctx, secret)
+			d.StreamListItem(ctx, Secret{Secret: secret})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -136,6 +157,14 @@ func getK8sSecret(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sSecret")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newSecretRow, schema.GroupKind{Kind: "Secret"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -154,12 +183,21 @@ func getK8sSecret(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 		return nil, err
 	}
 
-	return *secret, nil
+	return Secret{Secret: *secret}, nil
+}
+
+func newSecretRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.Secret
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return Secret{Secret: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformSecretTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.Secret)
+	obj := d.HydrateItem.(Secret)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
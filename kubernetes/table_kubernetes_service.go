@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// Service is a row of the kubernetes_service table
+type Service struct {
+	v1.Service
+	sourceInfo
+}
+
 func tableKubernetesService(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_service",
-		Description: "A service provides an abstract way to expose an application running on a set of Pods as a network service.",
+		Name:              "kubernetes_service",
+		Description:       "A service provides an abstract way to expose an application running on a set of Pods as a network service.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sService,
 		},
 		List: &plugin.ListConfig{
@@ -170,6 +178,15 @@ func listK8sServices(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sServices")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newServiceRow, schema.GroupKind{Kind: "Service"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -201,8 +218,12 @@ func listK8sServices(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.CoreV1().Services("").List(ctx, input)
+		response, err = clientset.CoreV1().Services(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sServices, err)
+			}
 			return nil, err
 		}
 
@@ -213,7 +234,7 @@ func listK8sServices(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		}
 
 		for _, service := range response.Items {
-			d.StreamListItem(ctx, service)
+			d.StreamListItem(ctx, Service{Service: service})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -228,6 +249,14 @@ func listK8sServices(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 func getK8sService(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("getK8sService")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newServiceRow, schema.GroupKind{Kind: "Service"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -242,12 +271,21 @@ func getK8sService(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDa
 		return nil, err
 	}
 
-	return *service, nil
+	return Service{Service: *service}, nil
+}
+
+func newServiceRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.Service
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return Service{Service: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformServiceTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.Service)
+	obj := d.HydrateItem.(Service)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
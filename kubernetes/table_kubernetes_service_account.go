@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// ServiceAccount is a row of the kubernetes_service_account table
+type ServiceAccount struct {
+	v1.ServiceAccount
+	sourceInfo
+}
+
 func tableKubernetesServiceAccount(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_service_account",
-		Description: "A service account provides an identity for processes that run in a Pod.",
+		Name:              "kubernetes_service_account",
+		Description:       "A service account provides an identity for processes that run in a Pod.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sServiceAccount,
 		},
 		List: &plugin.ListConfig{
@@ -65,6 +73,15 @@ func listK8sServiceAccounts(ctx context.Context, d *plugin.QueryData, _ *plugin.
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sServiceAccounts")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newServiceAccountRow, schema.GroupKind{Kind: "ServiceAccount"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -96,8 +113,12 @@ func listK8sServiceAccounts(ctx context.Context, d *plugin.QueryData, _ *plugin.
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.CoreV1().ServiceAccounts("").List(ctx, input)
+		response, err = clientset.CoreV1().ServiceAccounts(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sServiceAccounts, err)
+			}
 			return nil, err
 		}
 
@@ -108,7 +129,7 @@ func listK8sServiceAccounts(ctx context.Context, d *plugin.QueryData, _ *plugin.
 		}
 
 		for _, serviceAccount := range response.Items {
-			d.StreamListItem(ctx, serviceAccount)
+			d.StreamListItem(ctx, ServiceAccount{ServiceAccount: serviceAccount})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -124,6 +145,14 @@ func getK8sServiceAccount(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sServiceAccount")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newServiceAccountRow, schema.GroupKind{Kind: "ServiceAccount"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -143,12 +172,21 @@ func getK8sServiceAccount(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 		return nil, err
 	}
 
-	return *serviceAccount, nil
+	return ServiceAccount{ServiceAccount: *serviceAccount}, nil
+}
+
+func newServiceAccountRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.ServiceAccount
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return ServiceAccount{ServiceAccount: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformServiceAccountTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.ServiceAccount)
+	obj := d.HydrateItem.(ServiceAccount)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
@@ -6,18 +6,26 @@ import (
 
 	v1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
+// StatefulSet is a row of the kubernetes_stateful_set table
+type StatefulSet struct {
+	v1.StatefulSet
+	sourceInfo
+}
+
 func tableKubernetesStatefulSet(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "kubernetes_stateful_set",
-		Description: "A statefulSet is the workload API object used to manage stateful applications.",
+		Name:              "kubernetes_stateful_set",
+		Description:       "A statefulSet is the workload API object used to manage stateful applications.",
+		GetMatrixItemFunc: BuildContextNamespaceList,
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
+			KeyColumns: plugin.AllColumns([]string{"name", "namespace", "context_name"}),
 			Hydrate:    getK8sStatefulSet,
 		},
 		List: &plugin.ListConfig{
@@ -140,6 +148,15 @@ func listK8sStatefulSets(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 	logger := plugin.Logger(ctx)
 	logger.Trace("listK8sStatefulSets")
 
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newStatefulSetRow, schema.GroupKind{Group: "apps", Kind: "StatefulSet"})
+	}
+
+	namespace, ok, err := getQueryNamespace(ctx, d)
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -171,8 +188,12 @@ func listK8sStatefulSets(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 	pageLeft := true
 
 	for pageLeft {
-		response, err = clientset.AppsV1().StatefulSets("").List(ctx, input)
+		response, err = clientset.AppsV1().StatefulSets(namespace).List(ctx, input)
 		if err != nil {
+			// fall back to the namespaces the caller can access
+			if isClusterListForbidden(err, namespace, input) {
+				return listAccessibleNamespaces(ctx, d, listK8sStatefulSets, err)
+			}
 			return nil, err
 		}
 
@@ -183,7 +204,7 @@ func listK8sStatefulSets(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 		}
 
 		for _, statefulSet := range response.Items {
-			d.StreamListItem(ctx, statefulSet)
+			d.StreamListItem(ctx, StatefulSet{StatefulSet: statefulSet})
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.QueryStatus.RowsRemaining(ctx) == 0 {
@@ -199,6 +220,14 @@ func getK8sStatefulSet(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 	logger := plugin.Logger(ctx)
 	logger.Trace("getK8sStatefulSet")
 
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newStatefulSetRow, schema.GroupKind{Group: "apps", Kind: "StatefulSet"})
+	}
+
+	if _, ok, err := getQueryNamespace(ctx, d); err != nil || !ok {
+		return nil, err
+	}
+
 	clientset, err := GetNewClientset(ctx, d)
 	if err != nil {
 		return nil, err
@@ -218,12 +247,21 @@ func getK8sStatefulSet(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 		return nil, err
 	}
 
-	return *statefulSet, nil
+	return StatefulSet{StatefulSet: *statefulSet}, nil
+}
+
+func newStatefulSetRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.StatefulSet
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return StatefulSet{StatefulSet: obj, sourceInfo: manifest.sourceInfo}, nil
 }
 
 //// TRANSFORM FUNCTIONS
 
 func transformStatefulSetTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	obj := d.HydrateItem.(v1.StatefulSet)
+	obj := d.HydrateItem.(StatefulSet)
 	return mergeTags(obj.Labels, obj.Annotations), nil
 }
@@ -0,0 +1,221 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+
+	v1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// annotations marking the default storage class, with the beta annotation still honored by the API server
+const (
+	storageClassIsDefaultAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	storageClassBetaIsDefaultAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+)
+
+// StorageClass is a row of the kubernetes_storage_class table
+type StorageClass struct {
+	v1.StorageClass
+	sourceInfo
+}
+
+func tableKubernetesStorageClass(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_storage_class",
+		Description:       "A StorageClass describes a class of storage offered by the cluster, and the provisioner and parameters used to dynamically provision persistent volumes of that class.",
+		GetMatrixItemFunc: BuildContextList,
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
+			Hydrate:    getK8sStorageClass,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listK8sStorageClasses,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
+		},
+		Columns: k8sCommonGlobalColumns([]*plugin.Column{
+			{
+				Name:        "provisioner",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the volume plugin or CSI driver which provisions volumes of this class, e.g. ebs.csi.aws.com.",
+			},
+			{
+				Name:        "parameters",
+				Type:        proto.ColumnType_JSON,
+				Description: "Parameters passed to the provisioner when provisioning a volume of this class.",
+			},
+			{
+				Name:        "reclaim_policy",
+				Type:        proto.ColumnType_STRING,
+				Description: "What happens to volumes of this class when they are released from their claim: Delete (the default) or Retain.",
+				Transform:   transform.FromField("ReclaimPolicy"),
+			},
+			{
+				Name:        "volume_binding_mode",
+				Type:        proto.ColumnType_STRING,
+				Description: "When volumes of this class are provisioned and bound: Immediate (the default), or WaitForFirstConsumer to wait for a pod using the claim to be scheduled.",
+				Transform:   transform.FromField("VolumeBindingMode"),
+			},
+			{
+				Name:        "allow_volume_expansion",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if claims of this class can be resized.",
+				Transform:   transform.FromField("AllowVolumeExpansion"),
+			},
+			{
+				Name:        "is_default",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the class is annotated as the default storage class, used by claims which do not specify a class.",
+				Transform:   transform.From(transformStorageClassIsDefault),
+			},
+			{
+				Name:        "mount_options",
+				Type:        proto.ColumnType_JSON,
+				Description: "Mount options of volumes of this class, e.g. [\"ro\", \"soft\"].",
+			},
+			{
+				Name:        "allowed_topologies",
+				Type:        proto.ColumnType_JSON,
+				Description: "Topologies volumes of this class can be provisioned in. Empty means there is no restriction.",
+			},
+
+			//// Steampipe Standard Columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: ColumnDescriptionTitle,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Type:        proto.ColumnType_JSON,
+				Description: ColumnDescriptionTags,
+				Transform:   transform.From(transformStorageClassTags),
+			},
+		}),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sStorageClasses(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sStorageClasses")
+
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newStorageClassRow, schema.GroupKind{Group: "storage.k8s.io", Kind: "StorageClass"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	// Limiting the results
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < input.Limit {
+			if *limit < 1 {
+				input.Limit = 1
+			} else {
+				input.Limit = *limit
+			}
+		}
+	}
+
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
+	var response *v1.StorageClassList
+	pageLeft := true
+
+	for pageLeft {
+		response, err = clientset.StorageV1().StorageClasses().List(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+		} else {
+			pageLeft = false
+		}
+
+		for _, storageClass := range response.Items {
+			d.StreamListItem(ctx, StorageClass{StorageClass: storageClass})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getK8sStorageClass(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("getK8sStorageClass")
+
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newStorageClassRow, schema.GroupKind{Group: "storage.k8s.io", Kind: "StorageClass"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	name := d.KeyColumnQuals["name"].GetStringValue()
+
+	// return if name is empty
+	if name == "" {
+		return nil, nil
+	}
+
+	storageClass, err := clientset.StorageV1().StorageClasses().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return StorageClass{StorageClass: *storageClass}, nil
+}
+
+func newStorageClassRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.StorageClass
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return StorageClass{StorageClass: obj, sourceInfo: manifest.sourceInfo}, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func transformStorageClassIsDefault(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(StorageClass)
+	return obj.Annotations[storageClassIsDefaultAnnotation] == "true" || obj.Annotations[storageClassBetaIsDefaultAnnotation] == "true", nil
+}
+
+func transformStorageClassTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(StorageClass)
+	return mergeTags(obj.Labels, obj.Annotations), nil
+}
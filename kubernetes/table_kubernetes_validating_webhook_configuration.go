@@ -0,0 +1,140 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+// ValidatingWebhook is a row of the kubernetes_validating_webhook_configuration table
+type ValidatingWebhook struct {
+	admissionregistrationv1.ValidatingWebhook
+	admissionWebhookInfo
+	sourceInfo
+}
+
+func (w ValidatingWebhook) webhookClientConfig() admissionregistrationv1.WebhookClientConfig {
+	return w.ClientConfig
+}
+
+func (w ValidatingWebhook) webhookFailurePolicy() *admissionregistrationv1.FailurePolicyType {
+	return w.FailurePolicy
+}
+
+func (w ValidatingWebhook) webhookNamespaceSelector() *metav1.LabelSelector {
+	return w.NamespaceSelector
+}
+
+func (w ValidatingWebhook) webhookInfo() admissionWebhookInfo {
+	return w.admissionWebhookInfo
+}
+
+func tableKubernetesValidatingWebhookConfiguration(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_validating_webhook_configuration",
+		Description:       "The webhooks of every ValidatingWebhookConfiguration, with one row per webhook. Validating webhooks are called by the API server to accept or reject requests before objects are stored.",
+		GetMatrixItemFunc: BuildContextList,
+		List: &plugin.ListConfig{
+			Hydrate: listK8sValidatingWebhooks,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "configuration_name", Require: plugin.Optional},
+			},
+		},
+		Columns: admissionWebhookColumns(),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sValidatingWebhooks(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sValidatingWebhooks")
+
+	kubeSystemLabels, err := getKubeSystemNamespaceLabels(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	configurationName := d.KeyColumnQualString("configuration_name")
+
+	if isManifestSource(d) {
+		manifests, err := listManifestObjects(ctx, d, schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"})
+		if err != nil {
+			return nil, err
+		}
+		for _, manifest := range manifests {
+			var configuration admissionregistrationv1.ValidatingWebhookConfiguration
+			if err := manifest.decode(&configuration); err != nil {
+				return nil, fmt.Errorf("%s: %v", manifest.Path, err)
+			}
+			if configurationName != "" && configuration.Name != configurationName {
+				continue
+			}
+			if done := streamValidatingWebhooks(ctx, d, configuration, kubeSystemLabels, manifest.sourceInfo); done {
+				return nil, nil
+			}
+		}
+		return nil, nil
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	if configurationName != "" {
+		input.FieldSelector = fmt.Sprintf("metadata.name=%v", configurationName)
+	}
+
+	var response *admissionregistrationv1.ValidatingWebhookConfigurationList
+	pageLeft := true
+
+	for pageLeft {
+		response, err = clientset.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+		} else {
+			pageLeft = false
+		}
+
+		for _, configuration := range response.Items {
+			if done := streamValidatingWebhooks(ctx, d, configuration, kubeSystemLabels, sourceInfo{}); done {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// streamValidatingWebhooks :: stream a row per webhook of the configuration. done is true when
+// the query needs no more rows, e.g. because its limit has been hit.
+func streamValidatingWebhooks(ctx context.Context, d *plugin.QueryData, configuration admissionregistrationv1.ValidatingWebhookConfiguration, kubeSystemLabels labels.Set, source sourceInfo) (done bool) {
+	for _, webhook := range configuration.Webhooks {
+		d.StreamListItem(ctx, ValidatingWebhook{
+			ValidatingWebhook:    webhook,
+			admissionWebhookInfo: newAdmissionWebhookInfo(configuration.ObjectMeta, kubeSystemLabels),
+			sourceInfo:           source,
+		})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			return true
+		}
+	}
+	return false
+}
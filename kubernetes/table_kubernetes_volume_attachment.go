@@ -0,0 +1,226 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+
+	v1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// VolumeAttachment is a row of the kubernetes_volume_attachment table
+type VolumeAttachment struct {
+	v1.VolumeAttachment
+	sourceInfo
+}
+
+func tableKubernetesVolumeAttachment(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "kubernetes_volume_attachment",
+		Description:       "A VolumeAttachment records the intent to attach a volume to a node, or detach it, and the result reported by the attacher.",
+		GetMatrixItemFunc: BuildContextList,
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "context_name"}),
+			Hydrate:    getK8sVolumeAttachment,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listK8sVolumeAttachments,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+			},
+		},
+		Columns: k8sCommonGlobalColumns([]*plugin.Column{
+			{
+				Name:        "attacher",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the CSI driver or volume plugin which attaches the volume.",
+				Transform:   transform.FromField("Spec.Attacher"),
+			},
+			{
+				Name:        "node_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the node the volume is attached to.",
+				Transform:   transform.FromField("Spec.NodeName"),
+			},
+			{
+				Name:        "persistent_volume_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Name of the persistent volume to attach.",
+				Transform:   transform.FromField("Spec.Source.PersistentVolumeName"),
+			},
+			{
+				Name:        "inline_volume_spec",
+				Type:        proto.ColumnType_JSON,
+				Description: "Spec of an inline volume of a pod to attach, for volumes migrated from in-tree plugins to CSI.",
+				Transform:   transform.FromField("Spec.Source.InlineVolumeSpec"),
+			},
+			{
+				Name:        "attached",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if the volume has been attached.",
+				Transform:   transform.FromField("Status.Attached"),
+			},
+			{
+				Name:        "attachment_metadata",
+				Type:        proto.ColumnType_JSON,
+				Description: "Information returned by the attacher on a successful attach, passed on to later mount calls.",
+				Transform:   transform.FromField("Status.AttachmentMetadata"),
+			},
+			{
+				Name:        "attach_error_message",
+				Type:        proto.ColumnType_STRING,
+				Description: "Message of the last error attaching the volume, if any.",
+				Transform:   transform.FromField("Status.AttachError.Message"),
+			},
+			{
+				Name:        "attach_error_time",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time of the last error attaching the volume.",
+				Transform:   transform.FromField("Status.AttachError.Time").Transform(v1TimeToRFC3339),
+			},
+			{
+				Name:        "detach_error_message",
+				Type:        proto.ColumnType_STRING,
+				Description: "Message of the last error detaching the volume, if any.",
+				Transform:   transform.FromField("Status.DetachError.Message"),
+			},
+			{
+				Name:        "detach_error_time",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "Time of the last error detaching the volume.",
+				Transform:   transform.FromField("Status.DetachError.Time").Transform(v1TimeToRFC3339),
+			},
+
+			//// Steampipe Standard Columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: ColumnDescriptionTitle,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Type:        proto.ColumnType_JSON,
+				Description: ColumnDescriptionTags,
+				Transform:   transform.From(transformVolumeAttachmentTags),
+			},
+		}),
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+func listK8sVolumeAttachments(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("listK8sVolumeAttachments")
+
+	if isManifestSource(d) {
+		return nil, streamManifestObjects(ctx, d, newVolumeAttachmentRow, schema.GroupKind{Group: "storage.k8s.io", Kind: "VolumeAttachment"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := metav1.ListOptions{
+		Limit: 500,
+	}
+
+	// Limiting the results
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < input.Limit {
+			if *limit < 1 {
+				input.Limit = 1
+			} else {
+				input.Limit = *limit
+			}
+		}
+	}
+
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
+	var response *v1.VolumeAttachmentList
+	pageLeft := true
+
+	for pageLeft {
+		response, err = clientset.StorageV1().VolumeAttachments().List(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.GetContinue() != "" {
+			input.Continue = response.Continue
+		} else {
+			pageLeft = false
+		}
+
+		for _, volumeAttachment := range response.Items {
+			d.StreamListItem(ctx, VolumeAttachment{VolumeAttachment: volumeAttachment})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getK8sVolumeAttachment(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	logger.Trace("getK8sVolumeAttachment")
+
+	if isManifestSource(d) {
+		return getManifestObject(ctx, d, newVolumeAttachmentRow, schema.GroupKind{Group: "storage.k8s.io", Kind: "VolumeAttachment"})
+	}
+
+	clientset, err := GetNewClientset(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	name := d.KeyColumnQuals["name"].GetStringValue()
+
+	// return if name is empty
+	if name == "" {
+		return nil, nil
+	}
+
+	volumeAttachment, err := clientset.StorageV1().VolumeAttachments().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return VolumeAttachment{VolumeAttachment: *volumeAttachment}, nil
+}
+
+func newVolumeAttachmentRow(manifest manifestObject) (interface{}, error) {
+	var obj v1.VolumeAttachment
+	if err := manifest.decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return VolumeAttachment{VolumeAttachment: obj, sourceInfo: manifest.sourceInfo}, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func transformVolumeAttachmentTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	obj := d.HydrateItem.(VolumeAttachment)
+	return mergeTags(obj.Labels, obj.Annotations), nil
+}
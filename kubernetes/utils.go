@@ -7,10 +7,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	apiextension "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/azure"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -18,6 +20,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	metrics "k8s.io/metrics/pkg/client/clientset/versioned"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
@@ -26,81 +29,192 @@ import (
 
 // GetNewClientCRD :: gets client for querying k8s apis for CustomResourceDefinition
 func GetNewClientCRD(ctx context.Context, d *plugin.QueryData) (*apiextension.Clientset, error) {
-	// have we already created and cached the session?
-	serviceCacheKey := "GetNewClientCRD"
+	return getNewClientCRDForContext(ctx, d.Connection, getContextName(ctx, d))
+}
+
+// getNewClientCRDForContext :: gets client for querying CustomResourceDefinitions in the named kubeconfig context
+func getNewClientCRDForContext(ctx context.Context, connection *plugin.Connection, contextName string) (*apiextension.Clientset, error) {
+	// have we already created the client for this connection and context?
+	clientKey := newClientKey(connection, "GetNewClientCRD", contextName)
 
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
+	if cachedData, ok := clients.get(clientKey); ok {
 		return cachedData.(*apiextension.Clientset), nil
 	}
 
-	kubeconfig, err := getK8Config(ctx, d)
+	restconfig, err := getK8RestConfig(ctx, connection, contextName)
 	if err != nil {
-		plugin.Logger(ctx).Error("GetNewClientCRD", "getK8Config", err)
+		plugin.Logger(ctx).Error("GetNewClientCRD", "getK8RestConfig", err)
 		return nil, err
 	}
 
-	// Get a rest.Config from the kubeconfig file.
-	restconfig, err := kubeconfig.ClientConfig()
+	clientset, err := apiextension.NewForConfig(restconfig)
 	if err != nil {
-		// if .kube/config file is not available check for inClusterConfig
-		configErr := err
-		if strings.Contains(err.Error(), ".kube/config: no such file or directory") {
-			clientset, err := inClusterConfigCRD(ctx)
-			if err != nil {
-				return nil, errors.New(configErr.Error() + ", " + err.Error())
-			}
+		plugin.Logger(ctx).Error("GetNewClientCRD", "NewForConfig", err)
+		return nil, err
+	}
 
-			// save clientset in cache
-			d.ConnectionManager.Cache.Set(serviceCacheKey, clientset)
+	// save clientset in the registry
+	clients.set(clientKey, clientset)
 
-			return clientset, nil
-		}
+	return clientset, err
+}
+
+// GetNewClientDynamic :: gets dynamic client for querying k8s apis for resources without a typed client, e.g. custom resources
+func GetNewClientDynamic(ctx context.Context, d *plugin.QueryData) (dynamic.Interface, error) {
+	// have we already created the client for this connection and context?
+	contextName := getContextName(ctx, d)
+	clientKey := newClientKey(d.Connection, "GetNewClientDynamic", contextName)
 
+	if cachedData, ok := clients.get(clientKey); ok {
+		return cachedData.(dynamic.Interface), nil
+	}
+
+	restconfig, err := getK8RestConfig(ctx, d.Connection, contextName)
+	if err != nil {
+		plugin.Logger(ctx).Error("GetNewClientDynamic", "getK8RestConfig", err)
 		return nil, err
 	}
 
-	clientset, err := apiextension.NewForConfig(restconfig)
+	client, err := dynamic.NewForConfig(restconfig)
 	if err != nil {
-		plugin.Logger(ctx).Error("GetNewClientCRD", "NewForConfig", err)
+		plugin.Logger(ctx).Error("GetNewClientDynamic", "NewForConfig", err)
 		return nil, err
 	}
 
-	// save clientset in cache
-	d.ConnectionManager.Cache.Set(serviceCacheKey, clientset)
+	// save client in the registry
+	clients.set(clientKey, client)
 
-	return clientset, err
+	return client, err
 }
 
-func inClusterConfigCRD(ctx context.Context) (*apiextension.Clientset, error) {
-	clusterConfig, err := rest.InClusterConfig()
+// GetNewClientMetrics :: gets client for querying the metrics.k8s.io apis
+func GetNewClientMetrics(ctx context.Context, d *plugin.QueryData) (*metrics.Clientset, error) {
+	// have we already created the client for this connection and context?
+	contextName := getContextName(ctx, d)
+	clientKey := newClientKey(d.Connection, "GetNewClientMetrics", contextName)
+
+	if cachedData, ok := clients.get(clientKey); ok {
+		return cachedData.(*metrics.Clientset), nil
+	}
+
+	restconfig, err := getK8RestConfig(ctx, d.Connection, contextName)
 	if err != nil {
-		plugin.Logger(ctx).Error("inClusterConfigCRD", "InClusterConfig", err)
+		plugin.Logger(ctx).Error("GetNewClientMetrics", "getK8RestConfig", err)
 		return nil, err
 	}
 
-	clientset, err := apiextension.NewForConfig(clusterConfig)
+	clientset, err := metrics.NewForConfig(restconfig)
 	if err != nil {
-		plugin.Logger(ctx).Error("inClusterConfigCRD", "NewForConfig", err)
+		plugin.Logger(ctx).Error("GetNewClientMetrics", "NewForConfig", err)
 		return nil, err
 	}
 
-	return clientset, nil
+	// save clientset in the registry
+	clients.set(clientKey, clientset)
+
+	return clientset, err
 }
 
 // GetNewClientset :: gets client for querying k8s apis for the provided context
 func GetNewClientset(ctx context.Context, d *plugin.QueryData) (*kubernetes.Clientset, error) {
+	return getNewClientsetForContext(ctx, d.Connection, getContextName(ctx, d))
+}
+
+// getNewClientsetForContext :: gets client for querying k8s apis for the named kubeconfig context
+func getNewClientsetForContext(ctx context.Context, connection *plugin.Connection, contextName string) (*kubernetes.Clientset, error) {
 	logger := plugin.Logger(ctx)
 	logger.Trace("GetNewClientset")
 
-	// have we already created and cached the session?
-	serviceCacheKey := "k8sClient" //should probably per connection/context keys...
+	// have we already created the client for this connection and context?
+	clientKey := newClientKey(connection, "GetNewClientset", contextName)
+
+	if cachedData, ok := clients.get(clientKey); ok {
+		return cachedData.(*kubernetes.Clientset), nil
+	}
+
+	restconfig, err := getK8RestConfig(ctx, connection, contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(restconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// save clientset in the registry
+	clients.set(clientKey, clientset)
+
+	return clientset, err
+}
+
+// getNewClientsetForLogs :: gets client for streaming pod logs. request_timeout bounds the
+// whole response including its body, so it is not applied to log streams, which are read
+// for as long as the query needs lines.
+func getNewClientsetForLogs(ctx context.Context, d *plugin.QueryData) (*kubernetes.Clientset, error) {
+	// have we already created the client for this connection and context?
+	contextName := getContextName(ctx, d)
+	clientKey := newClientKey(d.Connection, "getNewClientsetForLogs", contextName)
 
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		// logger.Warn("!!!! Clientset Found in Cache !!!!")
+	if cachedData, ok := clients.get(clientKey); ok {
 		return cachedData.(*kubernetes.Clientset), nil
 	}
 
-	kubeconfig, err := getK8Config(ctx, d)
+	restconfig, err := getK8RestConfig(ctx, d.Connection, contextName)
+	if err != nil {
+		return nil, err
+	}
+	restconfig.Timeout = 0
+
+	clientset, err := kubernetes.NewForConfig(restconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// save clientset in the registry
+	clients.set(clientKey, clientset)
+
+	return clientset, err
+}
+
+// Get a rest.Config for the named kubeconfig context, with the connection's
+// impersonation and exec credential settings applied.
+func getK8RestConfig(ctx context.Context, connection *plugin.Connection, contextName string) (*rest.Config, error) {
+	// get kubernetes config info
+	kubernetesConfig := GetConfig(connection)
+
+	// the matrix is empty when config_contexts cannot be resolved, so report why here
+	if len(kubernetesConfig.ConfigContexts) > 0 && plugin.GetMatrixItem(ctx) == nil {
+		if _, err := getConfiguredContexts(ctx, connection); err != nil {
+			return nil, err
+		}
+	}
+
+	restconfig, err := loadK8RestConfig(ctx, connection, kubernetesConfig, contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyAuthConfig(restconfig, kubernetesConfig); err != nil {
+		return nil, err
+	}
+
+	if err := applyRequestConfig(restconfig, kubernetesConfig); err != nil {
+		return nil, err
+	}
+
+	return restconfig, nil
+}
+
+// Load a rest.Config for the named kubeconfig context. Inline credentials in the
+// connection config take precedence over the kubeconfig; if no kubeconfig file can be
+// found, the service account Kubernetes gives to pods is used.
+func loadK8RestConfig(ctx context.Context, connection *plugin.Connection, kubernetesConfig kubernetesConfig, contextName string) (*rest.Config, error) {
+	if kubernetesConfig.Host != nil {
+		return getInlineRestConfig(kubernetesConfig)
+	}
+
+	kubeconfig, err := getK8ConfigForContext(ctx, connection, contextName)
 	if err != nil {
 		return nil, err
 	}
@@ -111,88 +225,181 @@ func GetNewClientset(ctx context.Context, d *plugin.QueryData) (*kubernetes.Clie
 		// if .kube/config file is not available check for inClusterConfig
 		configErr := err
 		if strings.Contains(err.Error(), ".kube/config: no such file or directory") {
-			clientset, err := inClusterConfig(ctx)
+			clusterConfig, err := rest.InClusterConfig()
 			if err != nil {
+				plugin.Logger(ctx).Error("loadK8RestConfig", "InClusterConfig", err)
 				return nil, errors.New(configErr.Error() + ", " + err.Error())
 			}
 
-			// save clientset in cache
-			d.ConnectionManager.Cache.Set(serviceCacheKey, clientset)
-
-			return clientset, nil
+			return clusterConfig, nil
 		}
 
 		return nil, err
 	}
 
-	clientset, err := kubernetes.NewForConfig(restconfig)
-	if err != nil {
-		return nil, err
+	return restconfig, nil
+}
+
+// Apply the impersonation and exec credential plugin settings of the connection config.
+// An exec plugin replaces any other credentials, e.g. from the kubeconfig user entry.
+func applyAuthConfig(restconfig *rest.Config, kubernetesConfig kubernetesConfig) error {
+	if kubernetesConfig.ImpersonateUser != nil {
+		restconfig.Impersonate.UserName = *kubernetesConfig.ImpersonateUser
+	}
+	if kubernetesConfig.ImpersonateUID != nil {
+		restconfig.Impersonate.UID = *kubernetesConfig.ImpersonateUID
+	}
+	if len(kubernetesConfig.ImpersonateGroups) > 0 {
+		restconfig.Impersonate.Groups = kubernetesConfig.ImpersonateGroups
 	}
 
-	// save clientset in cache
-	d.ConnectionManager.Cache.Set(serviceCacheKey, clientset)
+	if kubernetesConfig.ExecCommand == nil {
+		return nil
+	}
 
-	// logger.Warn("@@@@@@@  GetNewClientset SET cache status ", "success", success)
-	// time.Sleep(5000 * time.Millisecond)
-	// if value, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-	// 	logger.Warn("!!!! Clientset added to cache !!!!")
-	// } else {
-	// 	logger.Warn("!!!! Clientset NOT Found in Cache after adding !!!!", "serviceCacheKey", serviceCacheKey, "Value", value)
-	// }
+	execConfig := &clientcmdapi.ExecConfig{
+		Command:         *kubernetesConfig.ExecCommand,
+		Args:            kubernetesConfig.ExecArgs,
+		APIVersion:      "client.authentication.k8s.io/v1beta1",
+		InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+	}
+	if kubernetesConfig.ExecAPIVersion != nil {
+		execConfig.APIVersion = *kubernetesConfig.ExecAPIVersion
+	}
+	for _, env := range kubernetesConfig.ExecEnv {
+		name, value, ok := strings.Cut(env, "=")
+		if !ok {
+			return fmt.Errorf("invalid exec_env entry %q, expected NAME=VALUE", env)
+		}
+		execConfig.Env = append(execConfig.Env, clientcmdapi.ExecEnvVar{Name: name, Value: value})
+	}
 
-	return clientset, err
+	restconfig.ExecProvider = execConfig
+	restconfig.AuthProvider = nil
+	restconfig.BearerToken = ""
+	restconfig.BearerTokenFile = ""
+	restconfig.Username = ""
+	restconfig.Password = ""
+	restconfig.TLSClientConfig.CertData = nil
+	restconfig.TLSClientConfig.CertFile = ""
+	restconfig.TLSClientConfig.KeyData = nil
+	restconfig.TLSClientConfig.KeyFile = ""
+
+	return nil
 }
 
-func inClusterConfig(ctx context.Context) (*kubernetes.Clientset, error) {
-	clusterConfig, err := rest.InClusterConfig()
-	if err != nil {
-		plugin.Logger(ctx).Error("InClusterConfig", "InClusterConfig", err)
-		return nil, err
+// Apply the client-side rate limits, request timeout and retries of the connection config
+func applyRequestConfig(restconfig *rest.Config, kubernetesConfig kubernetesConfig) error {
+	if kubernetesConfig.QPS != nil {
+		restconfig.QPS = float32(*kubernetesConfig.QPS)
+	}
+	if kubernetesConfig.Burst != nil {
+		restconfig.Burst = *kubernetesConfig.Burst
+	}
+
+	if kubernetesConfig.RequestTimeout != nil {
+		timeout, err := time.ParseDuration(*kubernetesConfig.RequestTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid request_timeout %q: %v", *kubernetesConfig.RequestTimeout, err)
+		}
+		restconfig.Timeout = timeout
+	}
+
+	// the retry round tripper also turns off client-go's own retries, so it is
+	// installed even when max_retries is 0
+	maxRetries := defaultMaxRetries
+	if kubernetesConfig.MaxRetries != nil {
+		maxRetries = *kubernetesConfig.MaxRetries
+	}
+	restconfig.Wrap(newRetryRoundTripper(maxRetries))
+
+	return nil
+}
+
+// Build a rest.Config from the host and credentials set in the connection config
+func getInlineRestConfig(kubernetesConfig kubernetesConfig) (*rest.Config, error) {
+	restconfig := &rest.Config{
+		Host: *kubernetesConfig.Host,
+	}
+
+	if kubernetesConfig.Token != nil {
+		restconfig.BearerToken = *kubernetesConfig.Token
+	}
+	if kubernetesConfig.TokenFile != nil {
+		path, err := homedir.Expand(*kubernetesConfig.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		restconfig.BearerTokenFile = path
 	}
 
-	clientset, err := kubernetes.NewForConfig(clusterConfig)
+	if kubernetesConfig.ClientCertificate != nil {
+		data, path, err := pemDataOrFile(*kubernetesConfig.ClientCertificate)
+		if err != nil {
+			return nil, err
+		}
+		restconfig.TLSClientConfig.CertData = data
+		restconfig.TLSClientConfig.CertFile = path
+	}
+	if kubernetesConfig.ClientKey != nil {
+		data, path, err := pemDataOrFile(*kubernetesConfig.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		restconfig.TLSClientConfig.KeyData = data
+		restconfig.TLSClientConfig.KeyFile = path
+	}
+	if kubernetesConfig.ClusterCACertificate != nil {
+		data, path, err := pemDataOrFile(*kubernetesConfig.ClusterCACertificate)
+		if err != nil {
+			return nil, err
+		}
+		restconfig.TLSClientConfig.CAData = data
+		restconfig.TLSClientConfig.CAFile = path
+	}
+
+	if kubernetesConfig.Insecure != nil {
+		restconfig.TLSClientConfig.Insecure = *kubernetesConfig.Insecure
+	}
+	if kubernetesConfig.TLSServerName != nil {
+		restconfig.TLSClientConfig.ServerName = *kubernetesConfig.TLSServerName
+	}
+
+	return restconfig, nil
+}
+
+// pemDataOrFile :: a certificate or key attribute holds either inline PEM data or a file path
+func pemDataOrFile(value string) ([]byte, string, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), "", nil
+	}
+
+	path, err := homedir.Expand(value)
 	if err != nil {
-		plugin.Logger(ctx).Error("InClusterConfig", "NewForConfig", err)
-		return nil, err
+		return nil, "", err
 	}
 
-	return clientset, nil
+	return nil, path, nil
 }
 
-// Get kubernetes config based on environment variable and plugin config
-func getK8Config(ctx context.Context, d *plugin.QueryData) (clientcmd.ClientConfig, error) {
+// Get kubernetes config based on environment variable and plugin config.
+// An empty contextName uses the current context of the kubeconfig.
+func getK8ConfigForContext(ctx context.Context, connection *plugin.Connection, contextName string) (clientcmd.ClientConfig, error) {
 	logger := plugin.Logger(ctx)
-	logger.Trace("getK8Config")
+	logger.Trace("getK8ConfigForContext")
 
-	// have we already created and cached the session?
-	cacheKey := "getK8Config" //should probably per connection/context keys...
+	// have we already loaded the config for this connection and context?
+	clientKey := newClientKey(connection, "getK8Config", contextName)
 
-	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+	if cachedData, ok := clients.get(clientKey); ok {
 		return cachedData.(clientcmd.ClientConfig), nil
 	}
 
-	// get kubernetes config info
-	kubernetesConfig := GetConfig(d.Connection)
-
 	// Set default loader and overriding rules
 	loader := &clientcmd.ClientConfigLoadingRules{}
 	overrides := &clientcmd.ConfigOverrides{}
 
-	// variable to store paths for kubernetes config
-	// default kube config path
-	var configPaths = []string{"~/.kube/config"}
-	// Error: invalid configuration: no configuration has been provided, try setting KUBERNETES_MASTER environment variable
-
-	if kubernetesConfig.ConfigPath != nil {
-		configPaths = []string{*kubernetesConfig.ConfigPath}
-	} else if kubernetesConfig.ConfigPaths != nil && len(kubernetesConfig.ConfigPaths) > 0 {
-		configPaths = kubernetesConfig.ConfigPaths
-	} else if v := os.Getenv("KUBE_CONFIG_PATHS"); v != "" {
-		configPaths = filepath.SplitList(v)
-	} else if v := os.Getenv("KUBERNETES_MASTER"); v != "" {
-		configPaths = []string{v}
-	}
+	configPaths := getKubeconfigPaths(GetConfig(connection))
 
 	if len(configPaths) > 0 {
 		expandedPaths := []string{}
@@ -212,76 +419,38 @@ func getK8Config(ctx context.Context, d *plugin.QueryData) (clientcmd.ClientConf
 			loader.Precedence = expandedPaths
 		}
 
-		// TODO -- Add other config options
-		// if kubernetesConfig.ConfigContext != nil {
-		// 	kubectx = *kubernetesConfig.ConfigContext
-		// }
-		// kubectx, ctxOk := d.GetOk("config_context")
-		// authInfo, authInfoOk := d.GetOk("config_context_auth_info")
-		// cluster, clusterOk := d.GetOk("config_context_cluster")
-		// if ctxOk || authInfoOk || clusterOk {
-		if kubernetesConfig.ConfigContext != nil {
-			// ctxSuffix := "; overridden context"
-			// if ctxOk {
-			overrides.CurrentContext = *kubernetesConfig.ConfigContext
-			// ctxSuffix += fmt.Sprintf("; overridden context ; config ctx: %s", overrides.CurrentContext)
+		if contextName != "" {
+			overrides.CurrentContext = contextName
 			logger.Debug("GetNewClientset", "Using custom current context: %q", overrides.CurrentContext)
-			// }
-
-			overrides.Context = clientcmdapi.Context{}
-
-			// TODO -- Add other config options
-			// if authInfoOk {
-			// 	overrides.Context.AuthInfo = authInfo.(string)
-			// 	ctxSuffix += fmt.Sprintf("; auth_info: %s", overrides.Context.AuthInfo)
-			// }
-			// if clusterOk {
-			// 	overrides.Context.Cluster = cluster.(string)
-			// 	ctxSuffix += fmt.Sprintf("; cluster: %s", overrides.Context.Cluster)
-			// }
-			logger.Debug("GetNewClientset", "Using overidden context: %#v", overrides.Context)
 		}
 	}
 
 	kubeconfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
 
-	// save the config in cache
-	d.ConnectionManager.Cache.Set(cacheKey, kubeconfig)
+	// save the config in the registry
+	clients.set(clientKey, kubeconfig)
 
 	return kubeconfig, nil
 }
 
-//// HYDRATE FUNCTIONS
-
-func getKubectlContext(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	cacheKey := "getKubectlContext"
-	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
-		// plugin.Logger(ctx).Warn("getKubectlContext", "######## CACHED CURRENT CONTEXT", cachedData.(string))
-		return cachedData.(string), nil
-	}
-
-	kubeconfig, err := getK8Config(ctx, d)
-	if err != nil {
-		return nil, err
-	}
-
-	rawConfig, _ := kubeconfig.RawConfig()
-	currentContext := rawConfig.CurrentContext
-
-	// get kubernetes config info
-	kubernetesConfig := GetConfig(d.Connection)
+// getKubeconfigPaths :: kubeconfig file paths for the connection, before home directory expansion
+func getKubeconfigPaths(kubernetesConfig kubernetesConfig) []string {
+	// variable to store paths for kubernetes config
+	// default kube config path
+	var configPaths = []string{"~/.kube/config"}
+	// Error: invalid configuration: no configuration has been provided, try setting KUBERNETES_MASTER environment variable
 
-	// If set in plugin's (~/.steampipe/config/kubernetes.spc) connection profile
-	if kubernetesConfig.ConfigContext != nil {
-		currentContext = *kubernetesConfig.ConfigContext
+	if kubernetesConfig.ConfigPath != nil {
+		configPaths = []string{*kubernetesConfig.ConfigPath}
+	} else if kubernetesConfig.ConfigPaths != nil && len(kubernetesConfig.ConfigPaths) > 0 {
+		configPaths = kubernetesConfig.ConfigPaths
+	} else if v := os.Getenv("KUBE_CONFIG_PATHS"); v != "" {
+		configPaths = filepath.SplitList(v)
+	} else if v := os.Getenv("KUBERNETES_MASTER"); v != "" {
+		configPaths = []string{v}
 	}
 
-	// plugin.Logger(ctx).Warn("getKubectlContext", "######## CURRENT CONTEXT", currentContext)
-
-	// save current context in cache
-	d.ConnectionManager.Cache.Set(cacheKey, currentContext)
-
-	return currentContext, nil
+	return configPaths
 }
 
 //// COMMON TRANSFORM FUNCTIONS
@@ -299,11 +468,22 @@ func v1TimeToRFC3339(_ context.Context, d *transform.TransformData) (interface{}
 			return nil, nil
 		}
 		return v.ToUnstructured(), nil
+	case v1.MicroTime:
+		return microTimeToRFC3339(&v), nil
+	case *v1.MicroTime:
+		return microTimeToRFC3339(v), nil
 	default:
 		return nil, fmt.Errorf("invalid time format %T! ", v)
 	}
 }
 
+func microTimeToRFC3339(t *v1.MicroTime) interface{} {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(v1.RFC3339Micro)
+}
+
 func labelSelectorToString(_ context.Context, d *transform.TransformData) (interface{}, error) {
 	if d.Value == nil {
 		return nil, nil